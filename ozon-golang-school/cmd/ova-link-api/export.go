@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	linkAPI "github.com/ozonva/ova-link-api/pkg/ova-link-api"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var exportFormats = map[string]linkAPI.BookmarkFormat{
+	"netscape": linkAPI.BookmarkFormat_BOOKMARK_FORMAT_NETSCAPE,
+	"html":     linkAPI.BookmarkFormat_BOOKMARK_FORMAT_NETSCAPE,
+	"csv":      linkAPI.BookmarkFormat_BOOKMARK_FORMAT_CSV,
+	"jsonl":    linkAPI.BookmarkFormat_BOOKMARK_FORMAT_JSON,
+	"json":     linkAPI.BookmarkFormat_BOOKMARK_FORMAT_JSON,
+	"atom":     linkAPI.BookmarkFormat_BOOKMARK_FORMAT_ATOM,
+}
+
+// runExport writes the links streamed by the ExportLinks RPC to a file or stdout.
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	addr := flags.String("addr", "localhost"+grpcPort, "address of the link API server")
+	userID := flags.Uint64("user", 0, "owner of the exported links")
+	format := flags.String("format", "jsonl", "file format: netscape, csv, jsonl or atom")
+	output := flags.String("o", "-", "output file, - for stdout")
+	tags := flags.String("tags", "", "comma separated tags to filter by")
+	allTags := flags.Bool("all-tags", false, "require every tag instead of any of them")
+	search := flags.String("search", "", "substring of url or description")
+	from := flags.String("from", "", "export links created at or after this RFC 3339 time")
+	to := flags.String("to", "", "export links created before this RFC 3339 time")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: ova-link-api export -user ID [-format FORMAT] [-o FILE] [filters]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	bookmarkFormat, ok := exportFormats[strings.ToLower(*format)]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if *userID == 0 {
+		flags.Usage()
+		return errors.New("user is required")
+	}
+
+	filter := &linkAPI.ListLinkFilter{UserId: userID, Search: *search}
+	if *tags != "" {
+		filter.Tags = strings.Split(*tags, ",")
+	}
+	if *allTags {
+		filter.TagMatch = linkAPI.ListLinkFilter_ALL
+	}
+	var err error
+	if filter.CreatedFrom, err = parseTimeFlag("from", *from); err != nil {
+		return err
+	}
+	if filter.CreatedTo, err = parseTimeFlag("to", *to); err != nil {
+		return err
+	}
+
+	file := os.Stdout
+	if *output != "-" {
+		if file, err = os.Create(*output); err != nil {
+			return err
+		}
+		defer file.Close()
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := linkAPI.NewLinkAPIClient(conn).ExportLinks(context.Background(), &linkAPI.ExportLinksRequest{
+		Filter: filter,
+		Format: bookmarkFormat,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(res.GetChunk()); err != nil {
+			return err
+		}
+	}
+}
+
+func parseTimeFlag(name string, value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -%s: %w", name, err)
+	}
+	return timestamppb.New(parsed), nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	linkAPI "github.com/ozonva/ova-link-api/pkg/ova-link-api"
+	"google.golang.org/grpc"
+)
+
+const importChunkSize = 64 << 10
+
+var importFormats = map[string]linkAPI.BookmarkFormat{
+	"auto":     linkAPI.BookmarkFormat_BOOKMARK_FORMAT_AUTO,
+	"netscape": linkAPI.BookmarkFormat_BOOKMARK_FORMAT_NETSCAPE,
+	"html":     linkAPI.BookmarkFormat_BOOKMARK_FORMAT_NETSCAPE,
+	"csv":      linkAPI.BookmarkFormat_BOOKMARK_FORMAT_CSV,
+	"json":     linkAPI.BookmarkFormat_BOOKMARK_FORMAT_JSON,
+	"atom":     linkAPI.BookmarkFormat_BOOKMARK_FORMAT_ATOM,
+}
+
+// runImport streams a bookmarks file to the ImportLinks RPC and prints the report.
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	addr := flags.String("addr", "localhost"+grpcPort, "address of the link API server")
+	userID := flags.Uint64("user", 0, "owner of the imported links")
+	format := flags.String("format", "auto", "file format: auto, netscape, csv, json or atom")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: ova-link-api import -user ID [-addr HOST:PORT] [-format FORMAT] FILE")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	bookmarkFormat, ok := importFormats[strings.ToLower(*format)]
+	if !ok {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if *userID == 0 || flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("user and file are required")
+	}
+
+	file := os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		var err error
+		if file, err = os.Open(path); err != nil {
+			return err
+		}
+		defer file.Close()
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := linkAPI.NewLinkAPIClient(conn).ImportLinks(context.Background())
+	if err != nil {
+		return err
+	}
+	err = stream.Send(&linkAPI.ImportLinksRequest{
+		Payload: &linkAPI.ImportLinksRequest_Header{
+			Header: &linkAPI.ImportLinksHeader{UserId: *userID, Format: bookmarkFormat},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	chunk := make([]byte, importChunkSize)
+	for {
+		n, err := file.Read(chunk)
+		if n > 0 {
+			sendErr := stream.Send(&linkAPI.ImportLinksRequest{
+				Payload: &linkAPI.ImportLinksRequest_Chunk{Chunk: chunk[:n]},
+			})
+			// The real error is returned by CloseAndRecv.
+			if sendErr == io.EOF {
+				break
+			}
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	printImportReport(os.Stdout, res)
+	return nil
+}
+
+func printImportReport(w io.Writer, res *linkAPI.ImportLinksResponse) {
+	for _, skipped := range res.GetSkipped() {
+		reason := skipped.GetError()
+		if skipped.GetDuplicate() {
+			reason = "duplicate"
+		}
+		fmt.Fprintf(w, "#%d %s: %s\n", skipped.GetIndex(), skipped.GetUrl(), reason)
+	}
+	fmt.Fprintf(w, "total: %d, imported: %d, duplicates: %d, failed: %d\n",
+		res.GetTotal(), res.GetAccepted(), res.GetDuplicates(), res.GetFailed())
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ozonva/ova-link-api/migrations"
+	"github.com/pressly/goose/v3"
+)
+
+// runMigrate applies migrations with goose built into the binary, the stock goose command
+// cannot run the Go migrations.
+func runMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dir := flags.String("dir", "migrations", "directory with migration files")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: ova-link-api migrate [-dir DIR] [up|down|status|version]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	command, commandArgs := "up", []string(nil)
+	if flags.NArg() > 0 {
+		command, commandArgs = flags.Arg(0), flags.Args()[1:]
+	}
+
+	db, err := sqlx.Open("pgx", dbDSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := goose.SetDialect("postgres"); err != nil {
+		return err
+	}
+	return goose.Run(command, db.DB, *dir, commandArgs...)
+}
@@ -2,6 +2,9 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/ozonva/ova-link-api/internal/repo"
 
@@ -9,18 +12,40 @@ import (
 
 	grpczerolog "github.com/jwreagor/grpc-zerolog"
 	"github.com/ozonva/ova-link-api/internal/flusher"
+	"github.com/ozonva/ova-link-api/internal/metrics"
 	"github.com/ozonva/ova-link-api/internal/saver"
+	"github.com/ozonva/ova-link-api/internal/wal"
 
 	"google.golang.org/grpc/grpclog"
 
 	"github.com/rs/zerolog"
 
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	grpc "github.com/ozonva/ova-link-api/pkg/ova-link-api"
 )
 
+const (
+	saverCapacity       = 10
+	saverQueueSize      = 10000
+	saverCloseTimeout   = 10 * time.Second
+	flushChunkSize      = repo.DefaultCopyThreshold
+	savePeriodInSeconds = 1
+
+	flushBreakerThreshold = 5
+	flushBreakerCooldown  = 30 * time.Second
+)
+
+var flushRetry = flusher.RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
 type LinkAPI struct {
 	grpc.LinkAPIServer
 	repo   repo.Repo
@@ -28,45 +53,180 @@ type LinkAPI struct {
 	logger zerolog.Logger
 }
 
-func NewLinkAPI(repo repo.Repo, logger zerolog.Logger) grpc.LinkAPIServer {
+// NewLinkAPI keeps links of imports in saverLog until they are flushed, a nil saverLog keeps them only in memory.
+// Links the flusher fails to write after all retries go to deadLetter, a nil deadLetter keeps them in the saver.
+// The saver flushes links of different users with flushWorkers in parallel.
+func NewLinkAPI(
+	repo repo.Repo,
+	logger zerolog.Logger,
+	metrics *metrics.Metrics,
+	saverLog *wal.Log,
+	deadLetter flusher.DeadLetter,
+	flushWorkers uint,
+) *LinkAPI {
 	api := &LinkAPI{}
 	api.repo = repo
-	api.saver = saver.NewTimeOutSaver(10, flusher.NewFlusher(3, api.repo), 1)
+	breaker := flusher.NewBreaker(flushBreakerThreshold, flushBreakerCooldown)
+	linkFlusher := flusher.NewShardedFlusher(
+		flushWorkers,
+		flusher.NewResilientFlusher(flushChunkSize, api.repo, metrics, flushRetry, breaker, deadLetter),
+	)
+	if saverLog != nil {
+		api.saver = saver.NewDurableSaver(saverCapacity, saverQueueSize, saver.OverflowBlock, linkFlusher, savePeriodInSeconds, metrics, saverLog, logger)
+	} else {
+		api.saver = saver.NewTimeOutSaver(saverCapacity, saverQueueSize, saver.OverflowBlock, linkFlusher, savePeriodInSeconds, metrics)
+	}
 	api.logger = logger
 	return api
 }
 
-func (api *LinkAPI) CreateLink(ctx context.Context, req *grpc.CreateLinkRequest) (*emptypb.Empty, error) {
+func (api *LinkAPI) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), saverCloseTimeout)
+	defer cancel()
+
+	unflushed, err := api.saver.Close(ctx)
+	if unflushed > 0 {
+		api.logger.Error().Err(err).Int("links", unflushed).Msg("saver closed with unflushed links")
+	}
+}
+
+func (api *LinkAPI) CreateLink(ctx context.Context, req *grpc.CreateLinkRequest) (*grpc.CreateLinkResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.CreateLinkResponse{}
+	entity, err := newLinkFromRequest(req)
+	if err != nil {
+		return res, statusError(err)
+	}
+	var result *link.Link
+	if req.GetUpsert() {
+		result, err = api.repo.UpsertEntity(ctx, *entity)
+	} else {
+		result, err = api.repo.AddEntity(ctx, *entity)
+	}
+	if err != nil {
+		return res, statusError(err)
+	}
+
+	res.Id = result.ID
+	res.DateCreated = timestamppb.New(result.CreatedAt)
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func (api *LinkAPI) MultiCreateLink(ctx context.Context, req *grpc.MultiCreateLinkRequest) (*grpc.MultiCreateLinkResponse, error) {
 	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
 	grpclog.Info(req)
 
-	entity := link.New(req.UserId, req.Url)
-	entity.Description = req.Description
-	entity.SetTagsAsSlice(req.Tags)
-	api.saver.Save(*entity)
-	api.saver.Close()
+	res := &grpc.MultiCreateLinkResponse{}
+	if err := validateMultiCreateLinkRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	entities := make([]link.Link, 0, len(req.GetLinks()))
+	accepted := make([]*grpc.MultiCreateLinkResult, 0, len(req.GetLinks()))
+	for i, linkReq := range req.GetLinks() {
+		result := &grpc.MultiCreateLinkResult{Index: uint64(i)}
+		res.Results = append(res.Results, result)
+
+		entity, err := newLinkFromRequest(linkReq)
+		if err != nil {
+			result.Error = err.Error()
+			res.Failed++
+			continue
+		}
+		entities = append(entities, *entity)
+		accepted = append(accepted, result)
+	}
+
+	saved, err := api.saver.SaveBatch(ctx, entities)
+	for i, result := range accepted {
+		if i < saved {
+			result.Accepted = true
+			res.Accepted++
+		} else {
+			result.Error = err.Error()
+			res.Failed++
+		}
+	}
 
-	res := &emptypb.Empty{}
 	grpclog.Info(res)
 	return res, nil
 }
 
+func (api *LinkAPI) MultiCreateLinkStream(stream grpc.LinkAPI_MultiCreateLinkStreamServer) error {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+
+	res := &grpc.MultiCreateLinkResponse{}
+	batch := make([]link.Link, 0, saverCapacity)
+	batchResults := make([]*grpc.MultiCreateLinkResult, 0, saverCapacity)
+	saveBatch := func() {
+		if len(batch) == 0 {
+			return
+		}
+		saved, err := api.saver.SaveBatch(stream.Context(), batch)
+		for i, result := range batchResults {
+			if i < saved {
+				result.Accepted = true
+				res.Accepted++
+			} else {
+				result.Error = err.Error()
+				res.Failed++
+			}
+		}
+		batch = make([]link.Link, 0, saverCapacity)
+		batchResults = batchResults[:0]
+	}
+
+	for index := uint64(0); ; index++ {
+		linkReq, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			saveBatch()
+			return err
+		}
+		grpclog.Info(linkReq)
+
+		result := &grpc.MultiCreateLinkResult{Index: index}
+		res.Results = append(res.Results, result)
+
+		entity, err := newLinkFromRequest(linkReq)
+		if err != nil {
+			result.Error = err.Error()
+			res.Failed++
+			continue
+		}
+		batch = append(batch, *entity)
+		batchResults = append(batchResults, result)
+		if len(batch) == saverCapacity {
+			saveBatch()
+		}
+	}
+	saveBatch()
+
+	grpclog.Info(res)
+	return stream.SendAndClose(res)
+}
+
 func (api *LinkAPI) DescribeLink(ctx context.Context, req *grpc.DescribeLinkRequest) (*grpc.DescribeLinkResponse, error) {
 	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
 	grpclog.Info(req)
 
 	res := &grpc.DescribeLinkResponse{}
-	result, err := api.repo.DescribeEntity(req.GetId())
+	if err := validateDescribeLinkRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	result, err := api.repo.DescribeEntity(ctx, req.GetId())
 	if err != nil {
-		return res, err
+		return res, statusError(err)
 	}
 
-	res.Id = result.ID
-	res.UserId = result.UserID
-	res.Description = result.Description
-	res.Url = result.Url
-	res.Tags = result.GetTagsAsSlice()
-	res.DateCreated = timestamppb.New(result.CreatedAt)
+	res = newDescribeLinkResponse(result)
 
 	grpclog.Info(res)
 	return res, nil
@@ -77,37 +237,367 @@ func (api *LinkAPI) ListLink(ctx context.Context, req *grpc.ListLinkRequest) (*g
 	grpclog.Info(req)
 
 	res := &grpc.ListLinkResponse{}
-	result, err := api.repo.ListEntities(*req.Limit, *req.Offset)
+	if err := validateListLinkRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	filter := newRepoFilter(req.GetFilter())
+	limit := listLimit(req.Limit)
+	var result []link.Link
+	var err error
+	if req.Offset != nil {
+		result, err = api.repo.ListEntities(ctx, filter, limit, req.GetOffset())
+	} else {
+		result, res.NextPageToken, err = api.listPage(ctx, filter, req.GetPageToken(), limit)
+	}
 	if err != nil {
-		return res, err
+		return res, statusError(err)
 	}
 
 	for _, entity := range result {
-		resEntity := &grpc.DescribeLinkResponse{
-			Id:          entity.ID,
-			UserId:      entity.UserID,
-			Description: entity.Description,
-			Url:         entity.Url,
-			Tags:        entity.GetTagsAsSlice(),
-			DateCreated: timestamppb.New(entity.CreatedAt),
-		}
+		res.Items = append(res.Items, newDescribeLinkResponse(&entity))
+	}
 
-		res.Items = append(res.Items, resEntity)
+	if req.GetWithTotalCount() {
+		totalCount, err := api.repo.CountEntities(ctx, filter)
+		if err != nil {
+			return res, statusError(err)
+		}
+		res.TotalCount = &totalCount
 	}
 
 	grpclog.Info(res)
 	return res, nil
 }
+
+func (api *LinkAPI) listPage(ctx context.Context, filter repo.Filter, pageToken string, limit uint64) ([]link.Link, string, error) {
+	var cursor *repo.Cursor
+	if pageToken != "" {
+		var err error
+		cursor, err = decodePageToken(pageToken, filter)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	result, err := api.repo.ListEntitiesAfter(ctx, filter, cursor, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if uint64(len(result)) <= limit {
+		return result, "", nil
+	}
+
+	result = result[:limit]
+	return result, encodePageToken(result[len(result)-1], filter), nil
+}
+
 func (api *LinkAPI) DeleteLink(ctx context.Context, req *grpc.DeleteLinkRequest) (*emptypb.Empty, error) {
 	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
 	grpclog.Info(req)
 
 	res := &emptypb.Empty{}
-	err := api.repo.DeleteEntity(req.GetId())
+	if err := validateDeleteLinkRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	err := api.repo.DeleteEntity(ctx, req.GetId())
 	if err != nil {
-		return res, err
+		return res, statusError(err)
 	}
 
 	grpclog.Info(res)
 	return res, nil
 }
+
+func (api *LinkAPI) RestoreLink(ctx context.Context, req *grpc.RestoreLinkRequest) (*emptypb.Empty, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &emptypb.Empty{}
+	if err := validateRestoreLinkRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	err := api.repo.RestoreEntity(ctx, req.GetId())
+	if err != nil {
+		return res, statusError(err)
+	}
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func (api *LinkAPI) PurgeDeletedLinks(ctx context.Context, req *grpc.PurgeDeletedLinksRequest) (*grpc.PurgeDeletedLinksResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.PurgeDeletedLinksResponse{}
+	if err := validatePurgeDeletedLinksRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	purged, err := api.repo.PurgeDeletedEntities(ctx, req.GetOlderThan().AsTime())
+	if err != nil {
+		return res, statusError(err)
+	}
+	res.Purged = purged
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func (api *LinkAPI) UpdateLink(ctx context.Context, req *grpc.UpdateLinkRequest) (*grpc.DescribeLinkResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.DescribeLinkResponse{}
+	fields, err := updateMaskFields(req.GetUpdateMask())
+	if err != nil {
+		return res, statusError(err)
+	}
+	if err := validateUpdateLinkRequest(req, fields); err != nil {
+		return res, statusError(err)
+	}
+
+	entity := link.Link{
+		ID:          req.GetId(),
+		Description: req.GetDescription(),
+	}
+	entity.SetUrl(req.GetUrl())
+	entity.SetTagsAsSlice(req.GetTags())
+
+	result, err := api.repo.UpdateEntity(ctx, entity, fields)
+	if err != nil {
+		return res, statusError(err)
+	}
+	res = newDescribeLinkResponse(result)
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+var updatableFields = []string{"url", "description", "tags"}
+
+func (api *LinkAPI) ListBrokenLinks(ctx context.Context, req *grpc.ListBrokenLinksRequest) (*grpc.ListBrokenLinksResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.ListBrokenLinksResponse{}
+	if err := validateListBrokenLinksRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	entities, err := api.repo.ListBrokenEntities(ctx, req.GetUserId(), listLimit(req.Limit), req.GetOffset())
+	if err != nil {
+		return res, statusError(err)
+	}
+	for i := range entities {
+		res.Items = append(res.Items, newDescribeLinkResponse(&entities[i]))
+	}
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func (api *LinkAPI) SearchLinks(ctx context.Context, req *grpc.SearchLinksRequest) (*grpc.SearchLinksResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.SearchLinksResponse{}
+	if err := validateSearchLinksRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	query, err := parseSearchQuery(req.GetQuery())
+	if err != nil {
+		return res, statusError(err)
+	}
+	v := &validator{}
+	v.tags("query", query.Tags)
+	if err := v.err(); err != nil {
+		return res, statusError(err)
+	}
+
+	filter := repo.Filter{UserID: req.GetUserId(), Tags: query.Tags, MatchAllTags: true}
+	limit := listLimit(req.Limit)
+	results, err := api.repo.SearchEntities(ctx, query.SearchQuery, filter, limit+1, req.GetOffset())
+	if err != nil {
+		return res, statusError(err)
+	}
+	if uint64(len(results)) > limit {
+		results = results[:limit]
+		res.HasMore = true
+	}
+
+	for i := range results {
+		res.Items = append(res.Items, &grpc.SearchLinksResult{
+			Link:     newDescribeLinkResponse(&results[i].Link),
+			Rank:     results[i].Rank,
+			Headline: results[i].Headline,
+		})
+	}
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func (api *LinkAPI) ListTags(ctx context.Context, req *grpc.ListTagsRequest) (*grpc.ListTagsResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.ListTagsResponse{}
+	if err := validateListTagsRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	tags, err := api.repo.ListTags(ctx, req.GetUserId())
+	if err != nil {
+		return res, statusError(err)
+	}
+	for _, tag := range tags {
+		res.Tags = append(res.Tags, &grpc.TagCount{Name: tag.Name, Count: tag.Count})
+	}
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func (api *LinkAPI) RenameTag(ctx context.Context, req *grpc.RenameTagRequest) (*grpc.RenameTagResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.RenameTagResponse{}
+	if err := validateRenameTagRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	updated, err := api.repo.RenameTag(ctx, req.GetUserId(), req.GetName(), req.GetNewName())
+	if err != nil {
+		return res, statusError(err)
+	}
+	res.Updated = updated
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func (api *LinkAPI) MergeTags(ctx context.Context, req *grpc.MergeTagsRequest) (*grpc.MergeTagsResponse, error) {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	res := &grpc.MergeTagsResponse{}
+	if err := validateMergeTagsRequest(req); err != nil {
+		return res, statusError(err)
+	}
+
+	updated, err := api.repo.MergeTags(ctx, req.GetUserId(), req.GetNames(), req.GetTarget())
+	if err != nil {
+		return res, statusError(err)
+	}
+	res.Updated = updated
+
+	grpclog.Info(res)
+	return res, nil
+}
+
+func updateMaskFields(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return updatableFields, nil
+	}
+
+	mask.Normalize()
+	fields := make([]string, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		if !isUpdatableField(path) {
+			return nil, &fieldError{field: "update_mask", description: fmt.Sprintf("field %q cannot be updated", path)}
+		}
+		fields = append(fields, path)
+	}
+
+	return fields, nil
+}
+
+func isUpdatableField(path string) bool {
+	for _, field := range updatableFields {
+		if field == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newRepoFilter(filter *grpc.ListLinkFilter) repo.Filter {
+	result := repo.Filter{
+		UserID:       filter.GetUserId(),
+		Tags:         filter.GetTags(),
+		MatchAllTags: filter.GetTagMatch() == grpc.ListLinkFilter_ALL,
+		Search:       filter.GetSearch(),
+		OnlyDeleted:  filter.GetOnlyDeleted(),
+	}
+	if filter.GetCreatedFrom() != nil {
+		result.CreatedFrom = filter.GetCreatedFrom().AsTime()
+	}
+	if filter.GetCreatedTo() != nil {
+		result.CreatedTo = filter.GetCreatedTo().AsTime()
+	}
+
+	return result
+}
+
+func newLinkFromRequest(req *grpc.CreateLinkRequest) (*link.Link, error) {
+	if err := validateCreateLinkRequest(req); err != nil {
+		return nil, err
+	}
+
+	entity := link.New(req.GetUserId(), req.GetUrl())
+	entity.Description = req.GetDescription()
+	entity.SetTagsAsSlice(req.GetTags())
+	return entity, nil
+}
+
+func newDescribeLinkResponse(entity *link.Link) *grpc.DescribeLinkResponse {
+	res := &grpc.DescribeLinkResponse{
+		Id:               entity.ID,
+		UserId:           entity.UserID,
+		Description:      entity.Description,
+		Url:              entity.Url,
+		CanonicalUrl:     entity.CanonicalUrl,
+		Tags:             entity.GetTagsAsSlice(),
+		DateCreated:      timestamppb.New(entity.CreatedAt),
+		DateUpdated:      timestamppb.New(entity.UpdatedAt),
+		Title:            entity.Title,
+		PageDescription:  entity.PageDescription,
+		ImageUrl:         entity.ImageUrl,
+		FaviconUrl:       entity.FaviconUrl,
+		PageCanonicalUrl: entity.PageCanonicalUrl,
+	}
+	if entity.DeletedAt.Valid {
+		res.DateDeleted = timestamppb.New(entity.DeletedAt.Time)
+	}
+	if entity.EnrichedAt.Valid {
+		res.DateEnriched = timestamppb.New(entity.EnrichedAt.Time)
+	}
+	if entity.LastCheck != nil {
+		res.Health = newLinkHealth(entity.LastCheck)
+	}
+
+	return res
+}
+
+func newLinkHealth(check *link.Check) *grpc.LinkHealth {
+	res := &grpc.LinkHealth{
+		Status:      grpc.LinkHealth_HEALTHY,
+		HttpStatus:  uint32(check.StatusCode),
+		FinalUrl:    check.FinalUrl,
+		Error:       check.Error,
+		DateChecked: timestamppb.New(check.CheckedAt),
+	}
+	if check.Broken() {
+		res.Status = grpc.LinkHealth_BROKEN
+	}
+
+	return res
+}
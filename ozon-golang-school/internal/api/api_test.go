@@ -1,22 +1,41 @@
 package api_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/ozonva/ova-link-api/internal/bookmarks"
 	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
 
 	"github.com/golang/mock/gomock"
 	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"github.com/ozonva/ova-link-api/internal/tracing"
+	"github.com/ozonva/ova-link-api/internal/tracing/tracingtest"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	api "github.com/ozonva/ova-link-api/internal/api"
-	grpc "github.com/ozonva/ova-link-api/pkg/ova-link-api"
 	ova_link_api "github.com/ozonva/ova-link-api/pkg/ova-link-api"
 )
 
@@ -25,7 +44,16 @@ type LinkMatcher struct {
 }
 
 func (lm LinkMatcher) Matches(x interface{}) bool {
-	entities := x.([]link.Link)
+	var entities []link.Link
+	switch value := x.(type) {
+	case link.Link:
+		entities = []link.Link{value}
+	case []link.Link:
+		entities = value
+	default:
+		return false
+	}
+
 	if len(entities) != len(lm.expected) {
 		return false
 	}
@@ -37,7 +65,7 @@ func (lm LinkMatcher) Matches(x interface{}) bool {
 		if entities[i].Description != lm.expected[i].Description {
 			return false
 		}
-		if entities[i].Tags != lm.expected[i].Tags {
+		if !reflect.DeepEqual(entities[i].GetTagsAsSlice(), lm.expected[i].GetTagsAsSlice()) {
 			return false
 		}
 		if entities[i].Url != lm.expected[i].Url {
@@ -52,19 +80,100 @@ func (lm LinkMatcher) String() string {
 	return fmt.Sprintf("is equal to %v (%T)", lm.expected, lm.expected)
 }
 
+type createLinkStream struct {
+	ova_link_api.LinkAPI_MultiCreateLinkStreamServer
+	requests []*ova_link_api.CreateLinkRequest
+	response *ova_link_api.MultiCreateLinkResponse
+}
+
+func (s *createLinkStream) Recv() (*ova_link_api.CreateLinkRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *createLinkStream) SendAndClose(res *ova_link_api.MultiCreateLinkResponse) error {
+	s.response = res
+	return nil
+}
+
+func (s *createLinkStream) Context() context.Context {
+	return context.Background()
+}
+
+type importLinksStream struct {
+	ova_link_api.LinkAPI_ImportLinksServer
+	requests []*ova_link_api.ImportLinksRequest
+	response *ova_link_api.ImportLinksResponse
+}
+
+func (s *importLinksStream) Recv() (*ova_link_api.ImportLinksRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *importLinksStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *importLinksStream) SendAndClose(res *ova_link_api.ImportLinksResponse) error {
+	s.response = res
+	return nil
+}
+
+func newImportLinksStream(userID uint64, format ova_link_api.BookmarkFormat, chunks ...string) *importLinksStream {
+	stream := &importLinksStream{}
+	stream.requests = append(stream.requests, &ova_link_api.ImportLinksRequest{
+		Payload: &ova_link_api.ImportLinksRequest_Header{
+			Header: &ova_link_api.ImportLinksHeader{UserId: userID, Format: format},
+		},
+	})
+	for _, chunk := range chunks {
+		stream.requests = append(stream.requests, &ova_link_api.ImportLinksRequest{
+			Payload: &ova_link_api.ImportLinksRequest_Chunk{Chunk: []byte(chunk)},
+		})
+	}
+	return stream
+}
+
+type exportLinksStream struct {
+	ova_link_api.LinkAPI_ExportLinksServer
+	ctx  context.Context
+	data bytes.Buffer
+	sent int
+}
+
+func (s *exportLinksStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *exportLinksStream) Send(res *ova_link_api.ExportLinksResponse) error {
+	s.data.Write(res.GetChunk())
+	s.sent++
+	return nil
+}
+
 var _ = Describe("Api", func() {
 	Context("Database", func() {
-		var API grpc.LinkAPIServer
+		var API *api.LinkAPI
 		var ctrl *gomock.Controller
 		var mockRepo *mocks.MockRepo
 
 		BeforeEach(func() {
 			ctrl = gomock.NewController(GinkgoT())
 			mockRepo = mocks.NewMockRepo(ctrl)
-			API = api.NewLinkAPI(mockRepo, zerolog.Nop())
+			API = api.NewLinkAPI(mockRepo, zerolog.Nop(), metrics.New(prometheus.NewRegistry()), nil, nil, 1)
 		})
 
 		AfterEach(func() {
+			API.Close()
 			ctrl.Finish()
 		})
 
@@ -75,10 +184,10 @@ var _ = Describe("Api", func() {
 				UserID:      1,
 				Url:         "https://test.com",
 				Description: "test description",
-				Tags:        "tag1#tag2",
+				Tags:        []string{"tag1", "tag2"},
 				CreatedAt:   selectTime,
 			}
-			mockRepo.EXPECT().DescribeEntity(gomock.Eq(uint64(1))).Times(1).Return(expected, nil)
+			mockRepo.EXPECT().DescribeEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).Return(expected, nil)
 
 			_, err := API.DescribeLink(
 				context.Background(),
@@ -90,8 +199,187 @@ var _ = Describe("Api", func() {
 			Expect(err).Should(Succeed())
 		})
 
+		It("Describe enriched link", func() {
+			enrichTime := time.Now()
+			entity := link.Link{ID: 1, UserID: 1, Url: "https://test.com"}
+			entity.Title = "Title"
+			entity.FaviconUrl = "https://test.com/favicon.ico"
+			entity.EnrichedAt.Time = enrichTime
+			entity.EnrichedAt.Valid = true
+			mockRepo.EXPECT().DescribeEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).Return(&entity, nil)
+
+			res, err := API.DescribeLink(context.Background(), &ova_link_api.DescribeLinkRequest{Id: 1})
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetTitle()).Should(Equal("Title"))
+			Expect(res.GetFaviconUrl()).Should(Equal("https://test.com/favicon.ico"))
+			Expect(res.GetDateEnriched().AsTime().Equal(enrichTime)).Should(BeTrue())
+		})
+
+		It("Describe link health", func() {
+			checkTime := time.Now()
+			entity := link.Link{ID: 1, UserID: 1, Url: "https://test.com"}
+			entity.LastCheck = &link.Check{LinkID: 1, StatusCode: 404, FinalUrl: "https://test.com/", CheckedAt: checkTime}
+			mockRepo.EXPECT().DescribeEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).Return(&entity, nil)
+
+			res, err := API.DescribeLink(context.Background(), &ova_link_api.DescribeLinkRequest{Id: 1})
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetHealth().GetStatus()).Should(Equal(ova_link_api.LinkHealth_BROKEN))
+			Expect(res.GetHealth().GetHttpStatus()).Should(Equal(uint32(404)))
+			Expect(res.GetHealth().GetFinalUrl()).Should(Equal("https://test.com/"))
+			Expect(res.GetHealth().GetDateChecked().AsTime().Equal(checkTime)).Should(BeTrue())
+		})
+
+		It("Describe unchecked link", func() {
+			mockRepo.EXPECT().DescribeEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).Return(&link.Link{ID: 1}, nil)
+
+			res, err := API.DescribeLink(context.Background(), &ova_link_api.DescribeLinkRequest{Id: 1})
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetHealth().GetStatus()).Should(Equal(ova_link_api.LinkHealth_UNKNOWN))
+		})
+
+		It("List broken links", func() {
+			userID := uint64(1)
+			broken := link.Link{ID: 2, UserID: 1, Url: "https://test.com"}
+			broken.LastCheck = &link.Check{LinkID: 2, Error: "connection refused"}
+			mockRepo.EXPECT().ListBrokenEntities(gomock.Any(), gomock.Eq(uint64(1)), gomock.Eq(uint64(20)), gomock.Eq(uint64(0))).
+				Times(1).Return([]link.Link{broken}, nil)
+
+			res, err := API.ListBrokenLinks(context.Background(), &ova_link_api.ListBrokenLinksRequest{UserId: &userID})
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetItems()).Should(HaveLen(1))
+			Expect(res.GetItems()[0].GetHealth().GetError()).Should(Equal("connection refused"))
+		})
+
+		It("List traced. Should pass the span of the interceptor to the repo.", func() {
+			provider, exporter := tracingtest.NewProvider()
+			otel.SetTracerProvider(provider)
+			defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+			var repoSpan trace.SpanContext
+			mockRepo.EXPECT().ListEntities(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+				DoAndReturn(func(ctx context.Context, filter repo.Filter, limit uint64, offset uint64) ([]link.Link, error) {
+					repoSpan = trace.SpanContextFromContext(ctx)
+					return []link.Link{}, nil
+				})
+
+			offset := uint64(0)
+			info := &grpc.UnaryServerInfo{FullMethod: "/ova.link.api.LinkAPI/ListLink"}
+			_, err := tracing.UnaryServerInterceptor()(context.Background(), &ova_link_api.ListLinkRequest{Offset: &offset}, info,
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return API.ListLink(ctx, req.(*ova_link_api.ListLinkRequest))
+				})
+
+			Expect(err).Should(Succeed())
+			spans := exporter.GetSpans()
+			Expect(spans).Should(HaveLen(1))
+			Expect(repoSpan.SpanID()).Should(Equal(spans[0].SpanContext.SpanID()))
+		})
+
+		It("List canceled by the client", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			mockRepo.EXPECT().ListEntities(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+				DoAndReturn(func(ctx context.Context, filter repo.Filter, limit uint64, offset uint64) ([]link.Link, error) {
+					cancel()
+					<-ctx.Done()
+					return nil, ctx.Err()
+				})
+
+			offset := uint64(0)
+			_, err := API.ListLink(ctx, &ova_link_api.ListLinkRequest{Offset: &offset})
+
+			Expect(status.Code(err)).Should(Equal(codes.Canceled))
+		})
+
+		It("List timed out in the repo", func() {
+			mockRepo.EXPECT().ListEntities(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+				Return(nil, context.DeadlineExceeded)
+
+			offset := uint64(0)
+			_, err := API.ListLink(context.Background(), &ova_link_api.ListLinkRequest{Offset: &offset})
+
+			Expect(status.Code(err)).Should(Equal(codes.DeadlineExceeded))
+		})
+
+		It("List broken links with too big limit", func() {
+			limit := uint64(1000)
+			_, err := API.ListBrokenLinks(context.Background(), &ova_link_api.ListBrokenLinksRequest{Limit: &limit})
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		It("Search links success", func() {
+			found := repo.SearchResult{Link: *link.New(1, "https://golang.org"), Rank: 0.6, Headline: "The <b>Go</b> language"}
+			found.ID = 2
+			mockRepo.EXPECT().SearchEntities(gomock.Any(),
+				gomock.Eq(repo.SearchQuery{Words: []string{"go"}}),
+				gomock.Eq(repo.Filter{UserID: 1, MatchAllTags: true}),
+				gomock.Eq(uint64(3)),
+				gomock.Eq(uint64(0)),
+			).Times(1).Return([]repo.SearchResult{found, found, found}, nil)
+
+			userID, limit := uint64(1), uint64(2)
+			res, err := API.SearchLinks(context.Background(), &ova_link_api.SearchLinksRequest{
+				Query:  "go",
+				UserId: &userID,
+				Limit:  &limit,
+			})
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetItems()).Should(HaveLen(2))
+			Expect(res.GetHasMore()).Should(BeTrue())
+			Expect(res.GetItems()[0].GetLink().GetId()).Should(Equal(uint64(2)))
+			Expect(res.GetItems()[0].GetRank()).Should(Equal(0.6))
+			Expect(res.GetItems()[0].GetHeadline()).Should(Equal("The <b>Go</b> language"))
+		})
+
+		DescribeTable("Search query parsing",
+			func(raw string, query repo.SearchQuery, tags []string) {
+				mockRepo.EXPECT().SearchEntities(gomock.Any(),
+					gomock.Eq(query),
+					gomock.Eq(repo.Filter{Tags: tags, MatchAllTags: true}),
+					gomock.Eq(uint64(21)),
+					gomock.Eq(uint64(0)),
+				).Times(1).Return(nil, nil)
+
+				_, err := API.SearchLinks(context.Background(), &ova_link_api.SearchLinksRequest{Query: raw})
+
+				Expect(err).Should(Succeed())
+			},
+			Entry("words", "  golang   generics ", repo.SearchQuery{Words: []string{"golang", "generics"}}, nil),
+			Entry("phrase", `"error handling" go`,
+				repo.SearchQuery{Words: []string{"go"}, Phrases: []string{"error handling"}}, nil),
+			Entry("exclusions", `go -java -"spring boot"`,
+				repo.SearchQuery{Words: []string{"go"}, Excluded: []string{"java", "spring boot"}}, nil),
+			Entry("tags", `tag:golang TAG:"web dev" grpc`,
+				repo.SearchQuery{Words: []string{"grpc"}}, []string{"golang", "web dev"}),
+			Entry("only tags", "tag:golang", repo.SearchQuery{}, []string{"golang"}),
+			Entry("lone dash is ignored", "go - grpc", repo.SearchQuery{Words: []string{"go", "grpc"}}, nil),
+		)
+
+		DescribeTable("Search query validation",
+			func(raw string) {
+				_, err := API.SearchLinks(context.Background(), &ova_link_api.SearchLinksRequest{Query: raw})
+
+				st := status.Convert(err)
+				Expect(st.Code()).Should(Equal(codes.InvalidArgument))
+				violations := st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()
+				Expect(violations).Should(HaveLen(1))
+				Expect(violations[0].GetField()).Should(HavePrefix("query"))
+			},
+			Entry("empty", "   "),
+			Entry("unterminated quote", `"error handling`),
+			Entry("only exclusions", "-java"),
+			Entry("empty tag", "go tag:"),
+			Entry("tag exclusion", "go -tag:java"),
+			Entry("invalid tag", "tag:"+strings.Repeat("a", 65)),
+		)
+
 		It("Describe error", func() {
-			mockRepo.EXPECT().DescribeEntity(gomock.Eq(uint64(1))).Times(1).
+			mockRepo.EXPECT().DescribeEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).
 				Return(nil, errors.New("something goes wrong"))
 
 			_, err := API.DescribeLink(
@@ -101,7 +389,48 @@ var _ = Describe("Api", func() {
 				},
 			)
 
-			Expect(err).Should(HaveOccurred())
+			Expect(status.Code(err)).Should(Equal(codes.Internal))
+		})
+
+		DescribeTable("Describe repo error to status code",
+			func(repoErr error, code codes.Code) {
+				mockRepo.EXPECT().DescribeEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).
+					Return(nil, fmt.Errorf("describe: %w", repoErr))
+
+				_, err := API.DescribeLink(
+					context.Background(),
+					&ova_link_api.DescribeLinkRequest{
+						Id: 1,
+					},
+				)
+
+				Expect(status.Code(err)).Should(Equal(code))
+			},
+			Entry("not found", repo.ErrNotFound, codes.NotFound),
+			Entry("conflict", repo.ErrConflict, codes.AlreadyExists),
+			Entry("invalid input", repo.ErrInvalidInput, codes.InvalidArgument),
+			Entry("unavailable", repo.ErrUnavailable, codes.Unavailable),
+			Entry("deadline exceeded", context.DeadlineExceeded, codes.DeadlineExceeded),
+		)
+
+		It("Invalid input with field violation", func() {
+			mockRepo.EXPECT().AddEntity(gomock.Any(), gomock.Any()).Times(1).
+				Return(nil, &repo.Error{Kind: repo.ErrInvalidInput, Field: "url", Err: errors.New("value too long")})
+
+			_, err := API.CreateLink(
+				context.Background(),
+				&ova_link_api.CreateLinkRequest{
+					UserId: 1,
+					Url:    "https://test.com",
+				},
+			)
+
+			st := status.Convert(err)
+			Expect(st.Code()).Should(Equal(codes.InvalidArgument))
+			Expect(st.Details()).Should(HaveLen(1))
+			badRequest := st.Details()[0].(*errdetails.BadRequest)
+			Expect(badRequest.GetFieldViolations()[0].GetField()).Should(Equal("url"))
+			Expect(badRequest.GetFieldViolations()[0].GetDescription()).Should(Equal("value too long"))
 		})
 
 		It("List success", func() {
@@ -115,7 +444,7 @@ var _ = Describe("Api", func() {
 					UserID:      1,
 					Url:         "https://test.com3",
 					Description: "test description3",
-					Tags:        "tag3#tag6",
+					Tags:        []string{"tag3", "tag6"},
 					CreatedAt:   selectTime1,
 				},
 				{
@@ -123,7 +452,7 @@ var _ = Describe("Api", func() {
 					UserID:      1,
 					Url:         "https://test.com4",
 					Description: "test description4",
-					Tags:        "tag4#tag7",
+					Tags:        []string{"tag4", "tag7"},
 					CreatedAt:   selectTime2,
 				},
 				{
@@ -131,12 +460,12 @@ var _ = Describe("Api", func() {
 					UserID:      3,
 					Url:         "https://test.com5",
 					Description: "test description5",
-					Tags:        "tag5#tag8",
+					Tags:        []string{"tag5", "tag8"},
 					CreatedAt:   selectTime3,
 				},
 			}
 
-			mockRepo.EXPECT().ListEntities(gomock.Eq(uint64(2)), gomock.Eq(uint64(2))).
+			mockRepo.EXPECT().ListEntities(gomock.Any(), gomock.Eq(repo.Filter{}), gomock.Eq(uint64(2)), gomock.Eq(uint64(2))).
 				Times(1).
 				Return(expected, nil)
 
@@ -153,8 +482,137 @@ var _ = Describe("Api", func() {
 			Expect(err).Should(Succeed())
 		})
 
+		It("List with filter success", func() {
+			createdFrom := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+			mockRepo.EXPECT().ListEntities(gomock.Any(),
+				gomock.Eq(repo.Filter{
+					UserID:       1,
+					Tags:         []string{"tag1", "tag2"},
+					MatchAllTags: true,
+					Search:       "test",
+					CreatedFrom:  createdFrom,
+				}),
+				gomock.Eq(uint64(10)),
+				gomock.Eq(uint64(0)),
+			).Times(1).Return([]link.Link{}, nil)
+
+			userID := uint64(1)
+			limit := uint64(10)
+			offset := uint64(0)
+			_, err := API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{
+					Limit:  &limit,
+					Offset: &offset,
+					Filter: &ova_link_api.ListLinkFilter{
+						UserId:      &userID,
+						Tags:        []string{"tag1", "tag2"},
+						TagMatch:    ova_link_api.ListLinkFilter_ALL,
+						Search:      "test",
+						CreatedFrom: timestamppb.New(createdFrom),
+					},
+				},
+			)
+
+			Expect(err).Should(Succeed())
+		})
+
+		It("List by pages", func() {
+			pageTime := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+			page := []link.Link{
+				{ID: 3, UserID: 1, Url: "https://test.com3", CreatedAt: pageTime},
+				{ID: 4, UserID: 1, Url: "https://test.com4", CreatedAt: pageTime},
+				{ID: 5, UserID: 1, Url: "https://test.com5", CreatedAt: pageTime},
+			}
+			gomock.InOrder(
+				mockRepo.EXPECT().ListEntitiesAfter(gomock.Any(), gomock.Eq(repo.Filter{}), gomock.Nil(), gomock.Eq(uint64(3))).
+					Times(1).Return(page, nil),
+				mockRepo.EXPECT().
+					ListEntitiesAfter(gomock.Any(),
+						gomock.Eq(repo.Filter{}),
+						gomock.Eq(&repo.Cursor{CreatedAt: pageTime.Local(), ID: 4}),
+						gomock.Eq(uint64(3)),
+					).
+					Times(1).Return(page[2:], nil),
+			)
+
+			limit := uint64(2)
+			res, err := API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{Limit: &limit},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetItems()).Should(HaveLen(2))
+			Expect(res.GetNextPageToken()).ShouldNot(BeEmpty())
+
+			res, err = API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{Limit: &limit, PageToken: res.GetNextPageToken()},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetItems()).Should(HaveLen(1))
+			Expect(res.GetNextPageToken()).Should(BeEmpty())
+		})
+
+		It("List with invalid page token", func() {
+			limit := uint64(2)
+			_, err := API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{Limit: &limit, PageToken: "not a token"},
+			)
+
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("List with a page token of another filter", func() {
+			pageTime := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+			mockRepo.EXPECT().ListEntitiesAfter(gomock.Any(), gomock.Eq(repo.Filter{Search: "go"}), gomock.Nil(), gomock.Eq(uint64(2))).
+				Times(1).Return([]link.Link{
+				{ID: 3, UserID: 1, Url: "https://test.com3", CreatedAt: pageTime},
+				{ID: 4, UserID: 1, Url: "https://test.com4", CreatedAt: pageTime},
+			}, nil)
+
+			limit := uint64(1)
+			res, err := API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{Limit: &limit, Filter: &ova_link_api.ListLinkFilter{Search: "go"}},
+			)
+			Expect(err).Should(Succeed())
+
+			_, err = API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{Limit: &limit, Filter: &ova_link_api.ListLinkFilter{Search: "rust"}, PageToken: res.GetNextPageToken()},
+			)
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		It("List with total count", func() {
+			mockRepo.EXPECT().ListEntities(gomock.Any(), gomock.Eq(repo.Filter{UserID: 1}), gomock.Eq(uint64(2)), gomock.Eq(uint64(0))).
+				Times(1).Return([]link.Link{}, nil)
+			mockRepo.EXPECT().CountEntities(gomock.Any(), gomock.Eq(repo.Filter{UserID: 1})).Times(1).Return(uint64(42), nil)
+
+			userID := uint64(1)
+			limit := uint64(2)
+			offset := uint64(0)
+			res, err := API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{
+					Limit:          &limit,
+					Offset:         &offset,
+					Filter:         &ova_link_api.ListLinkFilter{UserId: &userID},
+					WithTotalCount: true,
+				},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetTotalCount()).Should(Equal(uint64(42)))
+		})
+
 		It("List error", func() {
-			mockRepo.EXPECT().ListEntities(gomock.Eq(uint64(2)), gomock.Eq(uint64(2))).
+			mockRepo.EXPECT().ListEntities(gomock.Any(), gomock.Eq(repo.Filter{}), gomock.Eq(uint64(2)), gomock.Eq(uint64(2))).
 				Times(1).
 				Return(nil, errors.New("something goes wrong"))
 
@@ -172,7 +630,7 @@ var _ = Describe("Api", func() {
 		})
 
 		It("Delete success", func() {
-			mockRepo.EXPECT().DeleteEntity(gomock.Eq(uint64(1))).Times(1).Return(nil)
+			mockRepo.EXPECT().DeleteEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).Return(nil)
 
 			_, err := API.DeleteLink(
 				context.Background(),
@@ -185,7 +643,7 @@ var _ = Describe("Api", func() {
 		})
 
 		It("Delete error", func() {
-			mockRepo.EXPECT().DeleteEntity(gomock.Eq(uint64(1))).
+			mockRepo.EXPECT().DeleteEntity(gomock.Any(), gomock.Eq(uint64(1))).
 				Times(1).Return(errors.New("something goes wrong"))
 
 			_, err := API.DeleteLink(
@@ -198,25 +656,179 @@ var _ = Describe("Api", func() {
 			Expect(err).Should(HaveOccurred())
 		})
 
+		It("Restore success", func() {
+			mockRepo.EXPECT().RestoreEntity(gomock.Any(), gomock.Eq(uint64(1))).Times(1).Return(nil)
+
+			_, err := API.RestoreLink(
+				context.Background(),
+				&ova_link_api.RestoreLinkRequest{
+					Id: 1,
+				},
+			)
+
+			Expect(err).Should(Succeed())
+		})
+
+		It("Restore error", func() {
+			mockRepo.EXPECT().RestoreEntity(gomock.Any(), gomock.Eq(uint64(1))).
+				Times(1).Return(errors.New("something goes wrong"))
+
+			_, err := API.RestoreLink(
+				context.Background(),
+				&ova_link_api.RestoreLinkRequest{
+					Id: 1,
+				},
+			)
+
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Purge success", func() {
+			olderThan := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+			mockRepo.EXPECT().PurgeDeletedEntities(gomock.Any(), gomock.Eq(olderThan)).Times(1).Return(uint64(3), nil)
+
+			res, err := API.PurgeDeletedLinks(
+				context.Background(),
+				&ova_link_api.PurgeDeletedLinksRequest{
+					OlderThan: timestamppb.New(olderThan),
+				},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetPurged()).Should(Equal(uint64(3)))
+		})
+
+		It("Purge without older_than", func() {
+			_, err := API.PurgeDeletedLinks(
+				context.Background(),
+				&ova_link_api.PurgeDeletedLinksRequest{},
+			)
+
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("List deleted", func() {
+			deleteTime := time.Now()
+			deleted := link.Link{ID: 1, UserID: 1, Url: "https://test.com"}
+			deleted.DeletedAt.Time = deleteTime
+			deleted.DeletedAt.Valid = true
+			mockRepo.EXPECT().ListEntities(gomock.Any(), gomock.Eq(repo.Filter{OnlyDeleted: true}), gomock.Eq(uint64(2)), gomock.Eq(uint64(0))).
+				Times(1).Return([]link.Link{deleted}, nil)
+
+			limit := uint64(2)
+			offset := uint64(0)
+			res, err := API.ListLink(
+				context.Background(),
+				&ova_link_api.ListLinkRequest{
+					Limit:  &limit,
+					Offset: &offset,
+					Filter: &ova_link_api.ListLinkFilter{OnlyDeleted: true},
+				},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetItems()[0].GetDateDeleted().AsTime().Equal(deleteTime)).Should(BeTrue())
+		})
+
+		It("List tags success", func() {
+			userID := uint64(1)
+			mockRepo.EXPECT().ListTags(gomock.Any(), gomock.Eq(uint64(1))).Times(1).Return([]repo.TagCount{
+				{Name: "tag1", Count: 3},
+				{Name: "tag2", Count: 1},
+			}, nil)
+
+			res, err := API.ListTags(context.Background(), &ova_link_api.ListTagsRequest{UserId: &userID})
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetTags()).Should(HaveLen(2))
+			Expect(res.GetTags()[0].GetName()).Should(Equal("tag1"))
+			Expect(res.GetTags()[0].GetCount()).Should(Equal(uint64(3)))
+		})
+
+		It("List tags of all users", func() {
+			mockRepo.EXPECT().ListTags(gomock.Any(), gomock.Eq(uint64(0))).Times(1).Return([]repo.TagCount{}, nil)
+
+			res, err := API.ListTags(context.Background(), &ova_link_api.ListTagsRequest{})
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetTags()).Should(BeEmpty())
+		})
+
+		It("Rename tag success", func() {
+			userID := uint64(1)
+			mockRepo.EXPECT().RenameTag(gomock.Any(), gomock.Eq(uint64(1)), gomock.Eq("golang"), gomock.Eq("go")).
+				Times(1).Return(uint64(2), nil)
+
+			res, err := API.RenameTag(
+				context.Background(),
+				&ova_link_api.RenameTagRequest{UserId: &userID, Name: "golang", NewName: "go"},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetUpdated()).Should(Equal(uint64(2)))
+		})
+
+		It("Rename tag to existing one", func() {
+			userID := uint64(1)
+			mockRepo.EXPECT().RenameTag(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).Return(uint64(0), &repo.Error{Kind: repo.ErrConflict, Err: errors.New("tag in use")})
+
+			_, err := API.RenameTag(
+				context.Background(),
+				&ova_link_api.RenameTagRequest{UserId: &userID, Name: "golang", NewName: "go"},
+			)
+
+			Expect(status.Code(err)).Should(Equal(codes.AlreadyExists))
+		})
+
+		It("Merge tags success", func() {
+			userID := uint64(1)
+			mockRepo.EXPECT().MergeTags(gomock.Any(), gomock.Eq(uint64(1)), gomock.Eq([]string{"golang", "go-lang"}), gomock.Eq("go")).
+				Times(1).Return(uint64(5), nil)
+
+			res, err := API.MergeTags(
+				context.Background(),
+				&ova_link_api.MergeTagsRequest{UserId: &userID, Names: []string{"golang", "go-lang"}, Target: "go"},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetUpdated()).Should(Equal(uint64(5)))
+		})
+
+		It("Merge unknown tags", func() {
+			userID := uint64(1)
+			mockRepo.EXPECT().MergeTags(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(1).Return(uint64(0), repo.ErrNotFound)
+
+			_, err := API.MergeTags(
+				context.Background(),
+				&ova_link_api.MergeTagsRequest{UserId: &userID, Names: []string{"unknown"}, Target: "go"},
+			)
+
+			Expect(status.Code(err)).Should(Equal(codes.NotFound))
+		})
+
 		It("Create success", func() {
-			selectTime1 := time.Now()
+			createTime := time.Now()
 			insert := []link.Link{
 				{
 					ID:          0,
 					UserID:      1,
 					Url:         "https://test.com3",
 					Description: "test description3",
-					Tags:        "tag3#tag6",
-					CreatedAt:   selectTime1,
+					Tags:        []string{"tag3", "tag6"},
+					CreatedAt:   createTime,
 				},
 			}
 
 			linkMatcher := LinkMatcher{
 				expected: insert,
 			}
-			mockRepo.EXPECT().AddEntities(linkMatcher).Times(1).Return(nil)
+			created := insert[0]
+			created.ID = 7
+			mockRepo.EXPECT().AddEntity(gomock.Any(), linkMatcher).Times(1).Return(&created, nil)
 
-			_, err := API.CreateLink(
+			res, err := API.CreateLink(
 				context.Background(),
 				&ova_link_api.CreateLinkRequest{
 					UserId:      1,
@@ -225,8 +837,514 @@ var _ = Describe("Api", func() {
 					Tags:        []string{"tag3", "tag6"},
 				},
 			)
-			time.Sleep(1 * time.Second)
 			Expect(err).Should(Succeed())
+			Expect(res.GetId()).Should(Equal(uint64(7)))
+			Expect(res.GetDateCreated().AsTime().Equal(createTime)).Should(BeTrue())
+		})
+
+		It("Create duplicate returns existing id", func() {
+			mockRepo.EXPECT().AddEntity(gomock.Any(), gomock.Any()).Times(1).
+				Return(nil, &repo.Error{Kind: repo.ErrConflict, Field: "url", ID: 5, Err: errors.New("link 5 has the same url")})
+
+			_, err := API.CreateLink(
+				context.Background(),
+				&ova_link_api.CreateLinkRequest{UserId: 1, Url: "https://test.com/?utm_source=mail"},
+			)
+
+			st, _ := status.FromError(err)
+			Expect(st.Code()).Should(Equal(codes.AlreadyExists))
+			Expect(st.Details()).Should(HaveLen(1))
+			Expect(st.Details()[0].(*errdetails.ResourceInfo).GetResourceName()).Should(Equal("5"))
+		})
+
+		It("Create in upsert mode", func() {
+			createTime := time.Now()
+			existing := link.Link{ID: 5, UserID: 1, Url: "https://test.com", CreatedAt: createTime}
+			mockRepo.EXPECT().
+				UpsertEntity(gomock.Any(), gomock.AssignableToTypeOf(link.Link{})).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, entity link.Link) (*link.Link, error) {
+					Expect(entity.CanonicalUrl).Should(Equal("https://test.com"))
+					return &existing, nil
+				})
+
+			res, err := API.CreateLink(
+				context.Background(),
+				&ova_link_api.CreateLinkRequest{UserId: 1, Url: "http://TEST.com:80/", Tags: []string{"tag1"}, Upsert: true},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetId()).Should(Equal(uint64(5)))
+		})
+
+		It("Create error", func() {
+			mockRepo.EXPECT().AddEntity(gomock.Any(), gomock.Any()).Times(1).
+				Return(nil, errors.New("something goes wrong"))
+
+			_, err := API.CreateLink(
+				context.Background(),
+				&ova_link_api.CreateLinkRequest{
+					UserId: 1,
+					Url:    "https://test.com",
+				},
+			)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Update success", func() {
+			updateTime := time.Now()
+			expected := &link.Link{
+				ID:          1,
+				UserID:      1,
+				Url:         "https://test.com",
+				Description: "new description",
+				Tags:        []string{"tag1", "tag2"},
+				CreatedAt:   updateTime,
+				UpdatedAt:   updateTime,
+			}
+			entity := link.Link{ID: 1, Description: "new description", Url: "https://ignored.com", CanonicalUrl: "https://ignored.com"}
+			mockRepo.EXPECT().UpdateEntity(gomock.Any(), gomock.Eq(entity), gomock.Eq([]string{"description"})).
+				Times(1).Return(expected, nil)
+
+			res, err := API.UpdateLink(
+				context.Background(),
+				&ova_link_api.UpdateLinkRequest{
+					Id:          1,
+					Url:         "https://ignored.com",
+					Description: "new description",
+					UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+				},
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetDescription()).Should(Equal("new description"))
+			Expect(res.GetDateUpdated().AsTime().Equal(updateTime)).Should(BeTrue())
+		})
+
+		It("Update without mask changes every field", func() {
+			entity := link.Link{ID: 1, Url: "https://test.com", CanonicalUrl: "https://test.com", Tags: []string{"tag1"}}
+			mockRepo.EXPECT().UpdateEntity(gomock.Any(), gomock.Eq(entity), gomock.Eq([]string{"url", "description", "tags"})).
+				Times(1).Return(&entity, nil)
+
+			_, err := API.UpdateLink(
+				context.Background(),
+				&ova_link_api.UpdateLinkRequest{
+					Id:   1,
+					Url:  "https://test.com",
+					Tags: []string{"tag1"},
+				},
+			)
+
+			Expect(err).Should(Succeed())
+		})
+
+		It("Update with unknown mask field", func() {
+			mockRepo.EXPECT().UpdateEntity(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := API.UpdateLink(
+				context.Background(),
+				&ova_link_api.UpdateLinkRequest{
+					Id:         1,
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"user_id"}},
+				},
+			)
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		It("Update error", func() {
+			mockRepo.EXPECT().UpdateEntity(gomock.Any(), gomock.Any(), gomock.Eq([]string{"url"})).
+				Times(1).Return(nil, errors.New("something goes wrong"))
+
+			_, err := API.UpdateLink(
+				context.Background(),
+				&ova_link_api.UpdateLinkRequest{
+					Id:         1,
+					Url:        "https://test.com",
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"url"}},
+				},
+			)
+
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Multi create success", func() {
+			mockRepo.EXPECT().AddEntities(gomock.Any(), LinkMatcher{expected: []link.Link{
+				{UserID: 1, Url: "https://test.com1", Description: "test description1", Tags: []string{"tag1"}},
+				{UserID: 2, Url: "https://test.com2"},
+			}}).Times(1).Return(nil)
+
+			res, err := API.MultiCreateLink(
+				context.Background(),
+				&ova_link_api.MultiCreateLinkRequest{
+					Links: []*ova_link_api.CreateLinkRequest{
+						{UserId: 1, Url: "https://test.com1", Description: "test description1", Tags: []string{"tag1"}},
+						{UserId: 0, Url: "https://test.com"},
+						{UserId: 2, Url: "https://test.com2"},
+					},
+				},
+			)
+			API.Close()
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetAccepted()).Should(Equal(uint64(2)))
+			Expect(res.GetFailed()).Should(Equal(uint64(1)))
+			Expect(res.GetResults()).Should(HaveLen(3))
+			Expect(res.GetResults()[0].GetAccepted()).Should(BeTrue())
+			Expect(res.GetResults()[1].GetAccepted()).Should(BeFalse())
+			Expect(res.GetResults()[1].GetError()).ShouldNot(BeEmpty())
+			Expect(res.GetResults()[2].GetAccepted()).Should(BeTrue())
+		})
+
+		It("Multi create with canceled context. Should report links not saved as failed.", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			res, err := API.MultiCreateLink(
+				ctx,
+				&ova_link_api.MultiCreateLinkRequest{
+					Links: []*ova_link_api.CreateLinkRequest{
+						{UserId: 1, Url: "https://test.com1"},
+						{UserId: 2, Url: "https://test.com2"},
+					},
+				},
+			)
+			API.Close()
+
+			Expect(err).Should(Succeed())
+			Expect(res.GetAccepted()).Should(BeZero())
+			Expect(res.GetFailed()).Should(Equal(uint64(2)))
+			Expect(res.GetResults()[0].GetError()).Should(Equal(context.Canceled.Error()))
+		})
+
+		It("Multi create stream success", func() {
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Len(10)).Times(1).Return(nil)
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Len(3)).Times(1).Return(nil)
+
+			stream := &createLinkStream{}
+			for i := 1; i <= 13; i++ {
+				stream.requests = append(stream.requests, &ova_link_api.CreateLinkRequest{
+					UserId: uint64(i),
+					Url:    fmt.Sprintf("https://test.com%d", i),
+				})
+			}
+			stream.requests = append(stream.requests, &ova_link_api.CreateLinkRequest{UserId: 1})
+
+			err := API.MultiCreateLinkStream(stream)
+			API.Close()
+
+			Expect(err).Should(Succeed())
+			Expect(stream.response.GetAccepted()).Should(Equal(uint64(13)))
+			Expect(stream.response.GetFailed()).Should(Equal(uint64(1)))
+			Expect(stream.response.GetResults()[13].GetIndex()).Should(Equal(uint64(13)))
+			Expect(stream.response.GetResults()[13].GetAccepted()).Should(BeFalse())
+		})
+
+		It("Import links success", func() {
+			mockRepo.EXPECT().ExistingCanonicalUrls(gomock.Any(),
+				gomock.Eq(uint64(1)),
+				gomock.Eq([]string{"https://golang.org", "https://grpc.io", "https://example.com"}),
+			).Times(1).Return([]string{"https://grpc.io"}, nil)
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, entities []link.Link) error {
+				Expect(entities).Should(HaveLen(2))
+				Expect(entities[0].Url).Should(Equal("https://golang.org/"))
+				Expect(entities[0].Tags).Should(Equal([]string{"dev"}))
+				Expect(entities[0].CreatedAt).Should(Equal(time.Unix(1600000000, 0).UTC()))
+				Expect(entities[1].Url).Should(Equal("https://example.com/"))
+				return nil
+			})
+
+			stream := newImportLinksStream(1, ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_AUTO,
+				`<!DOCTYPE NETSCAPE-Bookmark-file-1><DL><p><DT><H3>dev</H3><DL><p>`,
+				`<DT><A HREF="https://golang.org/" ADD_DATE="1600000000">Go</A>`,
+				`<DT><A HREF="https://golang.org/?utm_source=mail">Go again</A>`,
+				`<DT><A HREF="https://grpc.io/">gRPC</A>`,
+				`<DT><A HREF="ftp://files.example.com/">Files</A>`,
+				`</DL><p><DT><A HREF="https://example.com/">Example</A></DL><p>`,
+			)
+			err := API.ImportLinks(stream)
+			API.Close()
+
+			Expect(err).Should(Succeed())
+			res := stream.response
+			Expect(res.GetTotal()).Should(Equal(uint64(5)))
+			Expect(res.GetAccepted()).Should(Equal(uint64(2)))
+			Expect(res.GetDuplicates()).Should(Equal(uint64(2)))
+			Expect(res.GetFailed()).Should(Equal(uint64(1)))
+			Expect(res.GetSkipped()).Should(HaveLen(3))
+			Expect(res.GetSkipped()[0].GetIndex()).Should(Equal(uint64(1)))
+			Expect(res.GetSkipped()[0].GetDuplicate()).Should(BeTrue())
+			Expect(res.GetSkipped()[1].GetIndex()).Should(Equal(uint64(3)))
+			Expect(res.GetSkipped()[1].GetError()).ShouldNot(BeEmpty())
+			Expect(res.GetSkipped()[2].GetUrl()).Should(Equal("https://grpc.io/"))
+			Expect(res.GetSkipped()[2].GetDuplicate()).Should(BeTrue())
+		})
+
+		It("Import links from a folder with '#' in its name", func() {
+			mockRepo.EXPECT().ExistingCanonicalUrls(gomock.Any(), gomock.Eq(uint64(1)), gomock.Any()).Times(1).Return(nil, nil)
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, entities []link.Link) error {
+				Expect(entities).Should(HaveLen(1))
+				Expect(entities[0].Tags).Should(Equal([]string{"C#"}))
+				return nil
+			})
+
+			stream := newImportLinksStream(1, ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_NETSCAPE,
+				`<!DOCTYPE NETSCAPE-Bookmark-file-1><DL><p><DT><H3>C#</H3><DL><p>`,
+				`<DT><A HREF="https://learn.microsoft.com/dotnet/csharp/">C# docs</A>`,
+				`</DL><p></DL><p>`,
+			)
+			err := API.ImportLinks(stream)
+			API.Close()
+
+			Expect(err).Should(Succeed())
+			Expect(stream.response.GetAccepted()).Should(Equal(uint64(1)))
+			Expect(stream.response.GetFailed()).Should(BeZero())
+		})
+
+		It("Import links without header", func() {
+			stream := &importLinksStream{requests: []*ova_link_api.ImportLinksRequest{
+				{Payload: &ova_link_api.ImportLinksRequest_Chunk{Chunk: []byte("[]")}},
+			}}
+
+			err := API.ImportLinks(stream)
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		It("Import malformed file", func() {
+			stream := newImportLinksStream(1, ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_JSON, `[{"url": `)
+
+			err := API.ImportLinks(stream)
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		It("Export links with cursor", func() {
+			createTime := time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+			page := make([]link.Link, 0, 500)
+			for i := 1; i <= 500; i++ {
+				entity := link.New(1, fmt.Sprintf("https://test.com/%d", i))
+				entity.ID = uint64(i)
+				entity.CreatedAt = createTime
+				page = append(page, *entity)
+			}
+			last := link.New(1, "https://golang.org/")
+			last.ID = 501
+			last.Description = "Go"
+			last.CreatedAt = createTime
+			last.SetTagsAsSlice([]string{"go"})
+
+			filter := repo.Filter{UserID: 1, Tags: []string{"go"}}
+			gomock.InOrder(
+				mockRepo.EXPECT().ListEntitiesAfter(gomock.Any(), gomock.Eq(filter), gomock.Nil(), gomock.Eq(uint64(500))).
+					Times(1).Return(page, nil),
+				mockRepo.EXPECT().ListEntitiesAfter(gomock.Any(),
+					gomock.Eq(filter),
+					gomock.Eq(&repo.Cursor{CreatedAt: createTime, ID: 500}),
+					gomock.Eq(uint64(500)),
+				).Times(1).Return([]link.Link{*last}, nil),
+			)
+
+			userID := uint64(1)
+			stream := &exportLinksStream{ctx: context.Background()}
+			err := API.ExportLinks(&ova_link_api.ExportLinksRequest{
+				Filter: &ova_link_api.ListLinkFilter{UserId: &userID, Tags: []string{"go"}},
+				Format: ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_NETSCAPE,
+			}, stream)
+
+			Expect(err).Should(Succeed())
+			Expect(stream.sent).Should(BeNumerically(">", 0))
+			exported, err := bookmarks.Parse(bookmarks.FormatAuto, &stream.data)
+			Expect(err).Should(Succeed())
+			Expect(exported).Should(HaveLen(501))
+			Expect(exported[500]).Should(Equal(bookmarks.Bookmark{
+				Url:         "https://golang.org/",
+				Description: "Go",
+				Tags:        []string{"go"},
+				CreatedAt:   createTime,
+			}))
+		})
+
+		It("Export and import round trip", func() {
+			userID := uint64(1)
+			entity := link.New(userID, "https://golang.org/")
+			entity.ID = 1
+			entity.Description = "Go"
+			entity.CreatedAt = time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)
+			entity.SetTagsAsSlice([]string{"go", "lang"})
+			mockRepo.EXPECT().ListEntitiesAfter(gomock.Any(), gomock.Any(), gomock.Nil(), gomock.Any()).Times(1).
+				Return([]link.Link{*entity}, nil)
+			mockRepo.EXPECT().ExistingCanonicalUrls(gomock.Any(), gomock.Eq(uint64(2)), gomock.Any()).Times(1).Return(nil, nil)
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, entities []link.Link) error {
+				Expect(entities).Should(HaveLen(1))
+				Expect(entities[0].Url).Should(Equal(entity.Url))
+				Expect(entities[0].Description).Should(Equal(entity.Description))
+				Expect(entities[0].GetTagsAsSlice()).Should(Equal(entity.GetTagsAsSlice()))
+				Expect(entities[0].CreatedAt).Should(Equal(entity.CreatedAt))
+				return nil
+			})
+
+			exported := &exportLinksStream{ctx: context.Background()}
+			err := API.ExportLinks(&ova_link_api.ExportLinksRequest{
+				Filter: &ova_link_api.ListLinkFilter{UserId: &userID},
+				Format: ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_ATOM,
+			}, exported)
+			Expect(err).Should(Succeed())
+
+			imported := newImportLinksStream(2, ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_AUTO, exported.data.String())
+			err = API.ImportLinks(imported)
+			API.Close()
+
+			Expect(err).Should(Succeed())
+			Expect(imported.response.GetAccepted()).Should(Equal(uint64(1)))
+		})
+
+		It("Export canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			userID := uint64(1)
+
+			err := API.ExportLinks(&ova_link_api.ExportLinksRequest{
+				Filter: &ova_link_api.ListLinkFilter{UserId: &userID},
+				Format: ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_JSON,
+			}, &exportLinksStream{ctx: ctx})
+
+			Expect(status.Code(err)).Should(Equal(codes.Canceled))
+		})
+
+		DescribeTable("Export validation",
+			func(req *ova_link_api.ExportLinksRequest, field string) {
+				err := API.ExportLinks(req, &exportLinksStream{ctx: context.Background()})
+
+				st := status.Convert(err)
+				Expect(st.Code()).Should(Equal(codes.InvalidArgument))
+				Expect(st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()[0].GetField()).Should(Equal(field))
+			},
+			Entry("without user", &ova_link_api.ExportLinksRequest{Format: ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_CSV}, "filter.user_id"),
+			Entry("zero user id", &ova_link_api.ExportLinksRequest{
+				Filter: &ova_link_api.ListLinkFilter{UserId: new(uint64)},
+				Format: ova_link_api.BookmarkFormat_BOOKMARK_FORMAT_CSV,
+			}, "filter.user_id"),
+			Entry("without format", &ova_link_api.ExportLinksRequest{
+				Filter: &ova_link_api.ListLinkFilter{UserId: proto.Uint64(1)},
+			}, "format"),
+		)
+
+		DescribeTable("Create validation",
+			func(req *ova_link_api.CreateLinkRequest, fields []string) {
+				mockRepo.EXPECT().AddEntity(gomock.Any(), gomock.Any()).Times(0)
+
+				_, err := API.CreateLink(context.Background(), req)
+
+				st := status.Convert(err)
+				Expect(st.Code()).Should(Equal(codes.InvalidArgument))
+				violated := make([]string, 0, len(fields))
+				for _, violation := range st.Details()[0].(*errdetails.BadRequest).GetFieldViolations() {
+					violated = append(violated, violation.GetField())
+				}
+				Expect(violated).Should(Equal(fields))
+			},
+			Entry("empty request", &ova_link_api.CreateLinkRequest{}, []string{"user_id", "url"}),
+			Entry("relative url", &ova_link_api.CreateLinkRequest{UserId: 1, Url: "/test"}, []string{"url"}),
+			Entry("ftp url", &ova_link_api.CreateLinkRequest{UserId: 1, Url: "ftp://test.com"}, []string{"url"}),
+			Entry(
+				"broken tags",
+				&ova_link_api.CreateLinkRequest{UserId: 1, Url: "https://test.com", Tags: []string{"ok", " ", strings.Repeat("a", 65)}},
+				[]string{"tags[1]", "tags[2]"},
+			),
+		)
+
+		It("List without limit uses default", func() {
+			mockRepo.EXPECT().ListEntitiesAfter(gomock.Any(), gomock.Eq(repo.Filter{}), gomock.Nil(), gomock.Eq(uint64(21))).
+				Times(1).Return([]link.Link{}, nil)
+
+			req := &ova_link_api.ListLinkRequest{}
+			_, err := API.ListLink(context.Background(), req)
+
+			Expect(err).Should(Succeed())
+			Expect(req.Limit).Should(BeNil())
+		})
+
+		DescribeTable("List validation",
+			func(req *ova_link_api.ListLinkRequest) {
+				_, err := API.ListLink(context.Background(), req)
+
+				Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+			},
+			Entry("zero limit", &ova_link_api.ListLinkRequest{Limit: new(uint64)}),
+			Entry("offset with page token", &ova_link_api.ListLinkRequest{Offset: new(uint64), PageToken: "token"}),
+			Entry("zero user id", &ova_link_api.ListLinkRequest{Filter: &ova_link_api.ListLinkFilter{UserId: new(uint64)}}),
+			Entry("empty tag", &ova_link_api.ListLinkRequest{Filter: &ova_link_api.ListLinkFilter{Tags: []string{""}}}),
+			Entry("reversed date range", &ova_link_api.ListLinkRequest{Filter: &ova_link_api.ListLinkFilter{
+				CreatedFrom: timestamppb.New(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)),
+				CreatedTo:   timestamppb.New(time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)),
+			}}),
+		)
+
+		It("List with too big limit", func() {
+			limit := uint64(1000)
+			_, err := API.ListLink(context.Background(), &ova_link_api.ListLinkRequest{Limit: &limit})
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		It("Describe without id", func() {
+			_, err := API.DescribeLink(context.Background(), &ova_link_api.DescribeLinkRequest{})
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		It("Update with invalid url", func() {
+			_, err := API.UpdateLink(
+				context.Background(),
+				&ova_link_api.UpdateLinkRequest{
+					Id:         1,
+					Url:        "not a url",
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"url"}},
+				},
+			)
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
+		})
+
+		DescribeTable("Tag requests validation",
+			func(call func() error) {
+				Expect(status.Code(call())).Should(Equal(codes.InvalidArgument))
+			},
+			Entry("list tags with zero user id", func() error {
+				_, err := API.ListTags(context.Background(), &ova_link_api.ListTagsRequest{UserId: new(uint64)})
+				return err
+			}),
+			Entry("rename without user id", func() error {
+				_, err := API.RenameTag(context.Background(), &ova_link_api.RenameTagRequest{Name: "golang", NewName: "go"})
+				return err
+			}),
+			Entry("rename without name", func() error {
+				_, err := API.RenameTag(context.Background(), &ova_link_api.RenameTagRequest{NewName: "go"})
+				return err
+			}),
+			Entry("rename to the same name", func() error {
+				_, err := API.RenameTag(context.Background(), &ova_link_api.RenameTagRequest{Name: "go", NewName: "go"})
+				return err
+			}),
+			Entry("merge without user id", func() error {
+				_, err := API.MergeTags(context.Background(), &ova_link_api.MergeTagsRequest{Names: []string{"golang"}, Target: "go"})
+				return err
+			}),
+			Entry("merge without names", func() error {
+				_, err := API.MergeTags(context.Background(), &ova_link_api.MergeTagsRequest{Target: "go"})
+				return err
+			}),
+			Entry("merge without target", func() error {
+				_, err := API.MergeTags(context.Background(), &ova_link_api.MergeTagsRequest{Names: []string{"golang"}})
+				return err
+			}),
+		)
+
+		It("Multi create without links", func() {
+			_, err := API.MultiCreateLink(context.Background(), &ova_link_api.MultiCreateLinkRequest{})
+
+			Expect(status.Code(err)).Should(Equal(codes.InvalidArgument))
 		})
 	})
 })
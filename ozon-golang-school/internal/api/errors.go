@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fieldError struct {
+	field       string
+	description string
+}
+
+func (e *fieldError) Error() string {
+	return e.field + ": " + e.description
+}
+
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var validationErr validationError
+	if errors.As(err, &validationErr) {
+		return invalidArgument(validationErr...)
+	}
+
+	var fieldErr *fieldError
+	if errors.As(err, &fieldErr) {
+		return invalidArgument(fieldErr)
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, repo.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repo.ErrConflict):
+		return alreadyExists(err)
+	case errors.Is(err, repo.ErrInvalidInput):
+		var repoErr *repo.Error
+		if errors.As(err, &repoErr) && repoErr.Field != "" {
+			return invalidArgument(&fieldError{field: repoErr.Field, description: repoErr.Err.Error()})
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repo.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func invalidArgument(fieldErrors ...*fieldError) error {
+	messages := make([]string, 0, len(fieldErrors))
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(fieldErrors))
+	for _, fieldErr := range fieldErrors {
+		messages = append(messages, fieldErr.Error())
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldErr.field,
+			Description: fieldErr.description,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, "invalid request: "+strings.Join(messages, ", "))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func alreadyExists(err error) error {
+	st := status.New(codes.AlreadyExists, err.Error())
+
+	var repoErr *repo.Error
+	if !errors.As(err, &repoErr) || repoErr.ID == 0 {
+		return st.Err()
+	}
+
+	detailed, detailsErr := st.WithDetails(&errdetails.ResourceInfo{
+		ResourceType: "link",
+		ResourceName: strconv.FormatUint(repoErr.ID, 10),
+		Description:  repoErr.Err.Error(),
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
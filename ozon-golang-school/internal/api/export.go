@@ -0,0 +1,96 @@
+package api
+
+import (
+	"bytes"
+
+	grpczerolog "github.com/jwreagor/grpc-zerolog"
+	"github.com/ozonva/ova-link-api/internal/bookmarks"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	grpc "github.com/ozonva/ova-link-api/pkg/ova-link-api"
+	"google.golang.org/grpc/grpclog"
+)
+
+const (
+	exportBatchSize = 500
+	exportChunkSize = 64 << 10
+)
+
+func (api *LinkAPI) ExportLinks(req *grpc.ExportLinksRequest, stream grpc.LinkAPI_ExportLinksServer) error {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+	grpclog.Info(req)
+
+	if err := validateExportLinksRequest(req); err != nil {
+		return statusError(err)
+	}
+
+	output := &exportStream{stream: stream}
+	writer, err := bookmarks.NewWriter(bookmarkFormats[req.GetFormat()], output)
+	if err != nil {
+		return statusError(err)
+	}
+
+	filter := newRepoFilter(req.GetFilter())
+	var cursor *repo.Cursor
+	var exported uint64
+	for {
+		if err := stream.Context().Err(); err != nil {
+			return statusError(err)
+		}
+
+		entities, err := api.repo.ListEntitiesAfter(stream.Context(), filter, cursor, exportBatchSize)
+		if err != nil {
+			return statusError(err)
+		}
+		for _, entity := range entities {
+			err := writer.Write(bookmarks.Bookmark{
+				Url:         entity.Url,
+				Description: entity.Description,
+				Tags:        entity.GetTagsAsSlice(),
+				CreatedAt:   entity.CreatedAt.UTC(),
+			})
+			if err != nil {
+				return statusError(err)
+			}
+		}
+		exported += uint64(len(entities))
+
+		if len(entities) < exportBatchSize {
+			break
+		}
+		last := entities[len(entities)-1]
+		cursor = &repo.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	if err := writer.Close(); err != nil {
+		return statusError(err)
+	}
+	if err := output.flush(); err != nil {
+		return statusError(err)
+	}
+
+	grpclog.Infof("exported %d links", exported)
+	return nil
+}
+
+// exportStream buffers the written document and sends it in chunks of exportChunkSize.
+type exportStream struct {
+	stream grpc.LinkAPI_ExportLinksServer
+	buffer bytes.Buffer
+}
+
+func (es *exportStream) Write(p []byte) (int, error) {
+	es.buffer.Write(p)
+	for es.buffer.Len() >= exportChunkSize {
+		if err := es.stream.Send(&grpc.ExportLinksResponse{Chunk: es.buffer.Next(exportChunkSize)}); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (es *exportStream) flush() error {
+	if es.buffer.Len() == 0 {
+		return nil
+	}
+	return es.stream.Send(&grpc.ExportLinksResponse{Chunk: es.buffer.Next(es.buffer.Len())})
+}
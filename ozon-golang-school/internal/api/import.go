@@ -0,0 +1,164 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	grpczerolog "github.com/jwreagor/grpc-zerolog"
+	"github.com/ozonva/ova-link-api/internal/bookmarks"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/utils"
+	grpc "github.com/ozonva/ova-link-api/pkg/ova-link-api"
+	"google.golang.org/grpc/grpclog"
+)
+
+const (
+	maxImportSize     = 32 << 20
+	importLookupChunk = 500
+)
+
+var bookmarkFormats = map[grpc.BookmarkFormat]bookmarks.Format{
+	grpc.BookmarkFormat_BOOKMARK_FORMAT_AUTO:     bookmarks.FormatAuto,
+	grpc.BookmarkFormat_BOOKMARK_FORMAT_NETSCAPE: bookmarks.FormatNetscape,
+	grpc.BookmarkFormat_BOOKMARK_FORMAT_CSV:      bookmarks.FormatCSV,
+	grpc.BookmarkFormat_BOOKMARK_FORMAT_JSON:     bookmarks.FormatJSON,
+	grpc.BookmarkFormat_BOOKMARK_FORMAT_ATOM:     bookmarks.FormatAtom,
+}
+
+func (api *LinkAPI) ImportLinks(stream grpc.LinkAPI_ImportLinksServer) error {
+	grpclog.SetLoggerV2(grpczerolog.New(api.logger))
+
+	header, data, err := receiveImport(stream)
+	if err != nil {
+		return statusError(err)
+	}
+	grpclog.Info(header)
+
+	parsed, err := bookmarks.Parse(bookmarkFormats[header.GetFormat()], bytes.NewReader(data))
+	if err != nil {
+		return statusError(&fieldError{field: "chunk", description: "cannot be parsed: " + err.Error()})
+	}
+
+	res := &grpc.ImportLinksResponse{Total: uint64(len(parsed))}
+	entities := make([]link.Link, 0, len(parsed))
+	indexes := make(map[string]uint64, len(parsed))
+	for index, bookmark := range parsed {
+		entity, err := newLinkFromBookmark(header.GetUserId(), bookmark)
+		if err != nil {
+			res.Failed++
+			res.Skipped = append(res.Skipped, &grpc.ImportLinksResult{Index: uint64(index), Url: bookmark.Url, Error: err.Error()})
+			continue
+		}
+		if _, ok := indexes[entity.CanonicalUrl]; ok {
+			res.Duplicates++
+			res.Skipped = append(res.Skipped, &grpc.ImportLinksResult{Index: uint64(index), Url: bookmark.Url, Duplicate: true})
+			continue
+		}
+		indexes[entity.CanonicalUrl] = uint64(index)
+		entities = append(entities, *entity)
+	}
+
+	entities, err = api.skipExisting(stream.Context(), header.GetUserId(), entities, indexes, res)
+	if err != nil {
+		return statusError(err)
+	}
+
+	saved, err := api.saver.SaveBatch(stream.Context(), entities)
+	res.Accepted = uint64(saved)
+	for _, entity := range entities[saved:] {
+		res.Failed++
+		res.Skipped = append(res.Skipped, &grpc.ImportLinksResult{Index: indexes[entity.CanonicalUrl], Url: entity.Url, Error: err.Error()})
+	}
+
+	grpclog.Info(res)
+	return stream.SendAndClose(res)
+}
+
+// receiveImport reads the header message followed by the file chunks.
+func receiveImport(stream grpc.LinkAPI_ImportLinksServer) (*grpc.ImportLinksHeader, []byte, error) {
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil, nil, &fieldError{field: "header", description: "is required"}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	header := req.GetHeader()
+	if err := validateImportLinksHeader(header); err != nil {
+		return nil, nil, err
+	}
+
+	var data bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return header, data.Bytes(), nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if req.GetHeader() != nil {
+			return nil, nil, &fieldError{field: "header", description: "must be sent only once"}
+		}
+		if data.Len()+len(req.GetChunk()) > maxImportSize {
+			return nil, nil, &fieldError{field: "chunk", description: "file must not be larger than 32MiB"}
+		}
+		data.Write(req.GetChunk())
+	}
+}
+
+func (api *LinkAPI) skipExisting(
+	ctx context.Context,
+	userID uint64,
+	entities []link.Link,
+	indexes map[string]uint64,
+	res *grpc.ImportLinksResponse,
+) ([]link.Link, error) {
+	existing := make(map[string]bool)
+	for _, chunk := range utils.SliceChunkLink(entities, importLookupChunk) {
+		canonicalUrls := make([]string, 0, len(chunk))
+		for _, entity := range chunk {
+			canonicalUrls = append(canonicalUrls, entity.CanonicalUrl)
+		}
+
+		found, err := api.repo.ExistingCanonicalUrls(ctx, userID, canonicalUrls)
+		if err != nil {
+			return nil, err
+		}
+		for _, canonicalUrl := range found {
+			existing[canonicalUrl] = true
+		}
+	}
+
+	result := entities[:0]
+	for _, entity := range entities {
+		if !existing[entity.CanonicalUrl] {
+			result = append(result, entity)
+			continue
+		}
+		res.Duplicates++
+		res.Skipped = append(res.Skipped, &grpc.ImportLinksResult{
+			Index:     indexes[entity.CanonicalUrl],
+			Url:       entity.Url,
+			Duplicate: true,
+		})
+	}
+	return result, nil
+}
+
+func newLinkFromBookmark(userID uint64, bookmark bookmarks.Bookmark) (*link.Link, error) {
+	entity, err := newLinkFromRequest(&grpc.CreateLinkRequest{
+		UserId:      userID,
+		Url:         bookmark.Url,
+		Description: bookmark.Description,
+		Tags:        bookmark.Tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !bookmark.CreatedAt.IsZero() {
+		entity.CreatedAt = bookmark.CreatedAt
+	}
+	return entity, nil
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/repo"
+)
+
+var (
+	errInvalidPageToken = &fieldError{field: "page_token", description: "is invalid"}
+	errPageTokenFilter  = &fieldError{field: "page_token", description: "was issued for another filter"}
+)
+
+// encodePageToken points after entity, the token also carries a hash of filter, so that the
+// next pages are not taken from a different result set.
+func encodePageToken(entity link.Link, filter repo.Filter) string {
+	token := fmt.Sprintf("%d:%d:%d", entity.CreatedAt.UnixNano(), entity.ID, filterHash(filter))
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+func decodePageToken(token string, filter repo.Filter) (*repo.Cursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	var createdAt int64
+	var id uint64
+	var hash uint64
+	if _, err := fmt.Sscanf(string(decoded), "%d:%d:%d", &createdAt, &id, &hash); err != nil {
+		return nil, errInvalidPageToken
+	}
+	if hash != filterHash(filter) {
+		return nil, errPageTokenFilter
+	}
+
+	return &repo.Cursor{CreatedAt: time.Unix(0, createdAt), ID: id}, nil
+}
+
+func filterHash(filter repo.Filter) uint64 {
+	// A Filter holds only plain values, it always encodes.
+	encoded, _ := json.Marshal(filter)
+	hash := fnv.New64a()
+	_, _ = hash.Write(encoded)
+	return hash.Sum64()
+}
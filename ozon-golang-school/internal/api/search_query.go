@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ozonva/ova-link-api/internal/repo"
+)
+
+const (
+	maxSearchQueryLength = 512
+	searchTagPrefix      = "tag:"
+)
+
+type searchQuery struct {
+	repo.SearchQuery
+	Tags []string
+}
+
+// parseSearchQuery splits a raw query into plain words, "quoted phrases",
+// -excluded terms and tag:name filters.
+func parseSearchQuery(raw string) (*searchQuery, error) {
+	if utf8.RuneCountInString(raw) > maxSearchQueryLength {
+		return nil, searchQueryError("must not be longer than %d characters", maxSearchQueryLength)
+	}
+
+	query := &searchQuery{}
+	rest := strings.TrimSpace(raw)
+	for rest != "" {
+		excluded := false
+		if len(rest) > 1 && rest[0] == '-' && !unicode.IsSpace(rune(rest[1])) {
+			excluded = true
+			rest = rest[1:]
+		}
+
+		isTag := strings.HasPrefix(strings.ToLower(rest), searchTagPrefix)
+		if isTag {
+			rest = rest[len(searchTagPrefix):]
+		}
+
+		var term string
+		var quoted bool
+		var err error
+		term, quoted, rest, err = nextSearchTerm(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case isTag && excluded:
+			return nil, searchQueryError("tag exclusions are not supported")
+		case isTag && term == "":
+			return nil, searchQueryError("tag filter must not be empty")
+		case isTag:
+			query.Tags = append(query.Tags, term)
+		case term == "", term == "-" && !quoted:
+		case excluded:
+			query.Excluded = append(query.Excluded, term)
+		case quoted:
+			query.Phrases = append(query.Phrases, term)
+		default:
+			query.Words = append(query.Words, term)
+		}
+
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+	}
+
+	if len(query.Words) == 0 && len(query.Phrases) == 0 && len(query.Tags) == 0 {
+		return nil, searchQueryError("must contain a search term or a tag filter")
+	}
+	return query, nil
+}
+
+func nextSearchTerm(s string) (term string, quoted bool, rest string, err error) {
+	if strings.HasPrefix(s, `"`) {
+		end := strings.IndexByte(s[1:], '"')
+		if end < 0 {
+			return "", false, "", searchQueryError("has an unterminated quote")
+		}
+		return strings.TrimSpace(s[1 : end+1]), true, s[end+2:], nil
+	}
+
+	end := strings.IndexFunc(s, unicode.IsSpace)
+	if end < 0 {
+		return s, false, "", nil
+	}
+	return s[:end], false, s[end:], nil
+}
+
+func searchQueryError(format string, args ...interface{}) *fieldError {
+	return &fieldError{field: "query", description: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	grpc "github.com/ozonva/ova-link-api/pkg/ova-link-api"
+)
+
+const (
+	defaultListLimit     = 20
+	maxListLimit         = 100
+	maxMultiCreateLinks  = 1000
+	maxUrlLength         = 2048
+	maxDescriptionLength = 1024
+	maxTags              = 32
+	maxTagLength         = 64
+)
+
+type validationError []*fieldError
+
+func (e validationError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Error())
+	}
+	return strings.Join(messages, ", ")
+}
+
+type validator struct {
+	errors validationError
+}
+
+func (v *validator) add(field string, format string, args ...interface{}) {
+	v.errors = append(v.errors, &fieldError{field: field, description: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return v.errors
+}
+
+func (v *validator) id(field string, id uint64) {
+	if id == 0 {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validator) url(field string, value string) {
+	if value == "" {
+		v.add(field, "is required")
+		return
+	}
+	if len(value) > maxUrlLength {
+		v.add(field, "must not be longer than %d bytes", maxUrlLength)
+		return
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+		v.add(field, "must be an absolute URL")
+		return
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		v.add(field, "must use http or https scheme")
+	}
+}
+
+func (v *validator) description(field string, value string) {
+	if utf8.RuneCountInString(value) > maxDescriptionLength {
+		v.add(field, "must not be longer than %d characters", maxDescriptionLength)
+	}
+}
+
+func (v *validator) tags(field string, tags []string) {
+	if len(tags) > maxTags {
+		v.add(field, "must not contain more than %d tags", maxTags)
+		return
+	}
+	for i, tag := range tags {
+		v.tag(fmt.Sprintf("%s[%d]", field, i), tag)
+	}
+}
+
+func (v *validator) tag(field string, value string) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		v.add(field, "must not be empty")
+	case utf8.RuneCountInString(value) > maxTagLength:
+		v.add(field, "must not be longer than %d characters", maxTagLength)
+	}
+}
+
+func (v *validator) createLink(req *grpc.CreateLinkRequest) {
+	v.id("user_id", req.GetUserId())
+	v.url("url", req.GetUrl())
+	v.description("description", req.GetDescription())
+	v.tags("tags", req.GetTags())
+}
+
+// limit accepts a missing limit, handlers replace it with listLimit.
+func (v *validator) limit(field string, limit *uint64) {
+	if limit != nil && (*limit == 0 || *limit > maxListLimit) {
+		v.add(field, "must be between 1 and %d", maxListLimit)
+	}
+}
+
+func (v *validator) filter(prefix string, filter *grpc.ListLinkFilter) {
+	if filter != nil && filter.UserId != nil {
+		v.id(prefix+"user_id", filter.GetUserId())
+	}
+	v.tags(prefix+"tags", filter.GetTags())
+	if filter.GetCreatedFrom() != nil && filter.GetCreatedTo() != nil &&
+		!filter.GetCreatedFrom().AsTime().Before(filter.GetCreatedTo().AsTime()) {
+		v.add(prefix+"created_to", "must be after "+prefix+"created_from")
+	}
+}
+
+func validateCreateLinkRequest(req *grpc.CreateLinkRequest) error {
+	v := &validator{}
+	v.createLink(req)
+	return v.err()
+}
+
+func validateMultiCreateLinkRequest(req *grpc.MultiCreateLinkRequest) error {
+	v := &validator{}
+	if len(req.GetLinks()) == 0 {
+		v.add("links", "must not be empty")
+	}
+	if len(req.GetLinks()) > maxMultiCreateLinks {
+		v.add("links", "must not contain more than %d links", maxMultiCreateLinks)
+	}
+	return v.err()
+}
+
+func validateDescribeLinkRequest(req *grpc.DescribeLinkRequest) error {
+	v := &validator{}
+	v.id("id", req.GetId())
+	return v.err()
+}
+
+func validateDeleteLinkRequest(req *grpc.DeleteLinkRequest) error {
+	v := &validator{}
+	v.id("id", req.GetId())
+	return v.err()
+}
+
+func validateRestoreLinkRequest(req *grpc.RestoreLinkRequest) error {
+	v := &validator{}
+	v.id("id", req.GetId())
+	return v.err()
+}
+
+func validatePurgeDeletedLinksRequest(req *grpc.PurgeDeletedLinksRequest) error {
+	v := &validator{}
+	if req.GetOlderThan() == nil {
+		v.add("older_than", "is required")
+	} else if err := req.GetOlderThan().CheckValid(); err != nil {
+		v.add("older_than", "must be a valid timestamp")
+	}
+	return v.err()
+}
+
+func validateUpdateLinkRequest(req *grpc.UpdateLinkRequest, fields []string) error {
+	v := &validator{}
+	v.id("id", req.GetId())
+	for _, field := range fields {
+		switch field {
+		case "url":
+			v.url("url", req.GetUrl())
+		case "description":
+			v.description("description", req.GetDescription())
+		case "tags":
+			v.tags("tags", req.GetTags())
+		}
+	}
+	return v.err()
+}
+
+func validateListLinkRequest(req *grpc.ListLinkRequest) error {
+	v := &validator{}
+	v.limit("limit", req.Limit)
+	if req.Offset != nil && req.GetPageToken() != "" {
+		v.add("offset", "must not be set together with page_token")
+	}
+
+	v.filter("filter.", req.GetFilter())
+	return v.err()
+}
+
+func validateListTagsRequest(req *grpc.ListTagsRequest) error {
+	v := &validator{}
+	if req.UserId != nil {
+		v.id("user_id", req.GetUserId())
+	}
+	return v.err()
+}
+
+func validateRenameTagRequest(req *grpc.RenameTagRequest) error {
+	v := &validator{}
+	v.id("user_id", req.GetUserId())
+	v.tag("name", req.GetName())
+	v.tag("new_name", req.GetNewName())
+	if req.GetName() != "" && req.GetName() == req.GetNewName() {
+		v.add("new_name", "must differ from name")
+	}
+	return v.err()
+}
+
+func validateMergeTagsRequest(req *grpc.MergeTagsRequest) error {
+	v := &validator{}
+	v.id("user_id", req.GetUserId())
+	if len(req.GetNames()) == 0 {
+		v.add("names", "must not be empty")
+	}
+	v.tags("names", req.GetNames())
+	v.tag("target", req.GetTarget())
+	return v.err()
+}
+
+func validateListBrokenLinksRequest(req *grpc.ListBrokenLinksRequest) error {
+	v := &validator{}
+	v.limit("limit", req.Limit)
+	if req.UserId != nil {
+		v.id("user_id", req.GetUserId())
+	}
+	return v.err()
+}
+
+func validateSearchLinksRequest(req *grpc.SearchLinksRequest) error {
+	v := &validator{}
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		v.add("query", "is required")
+	}
+	v.limit("limit", req.Limit)
+	if req.UserId != nil {
+		v.id("user_id", req.GetUserId())
+	}
+	return v.err()
+}
+
+func validateImportLinksHeader(header *grpc.ImportLinksHeader) error {
+	v := &validator{}
+	if header == nil {
+		v.add("header", "must be sent first")
+		return v.err()
+	}
+	v.id("header.user_id", header.GetUserId())
+	if _, ok := bookmarkFormats[header.GetFormat()]; !ok {
+		v.add("header.format", "is not supported")
+	}
+	return v.err()
+}
+
+func validateExportLinksRequest(req *grpc.ExportLinksRequest) error {
+	v := &validator{}
+	if req.GetFilter() == nil || req.GetFilter().UserId == nil {
+		v.add("filter.user_id", "is required")
+	}
+	v.filter("filter.", req.GetFilter())
+	if _, ok := bookmarkFormats[req.GetFormat()]; !ok || req.GetFormat() == grpc.BookmarkFormat_BOOKMARK_FORMAT_AUTO {
+		v.add("format", "is required")
+	}
+	return v.err()
+}
+
+// listLimit returns the page size of a validated request, defaultListLimit if it is not set.
+func listLimit(limit *uint64) uint64 {
+	if limit == nil {
+		return defaultListLimit
+	}
+	return *limit
+}
@@ -0,0 +1,113 @@
+package bookmarks
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	XMLName    xml.Name       `xml:"entry"`
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// ParseAtom reads feeds written by NewAtomWriter: the link of every entry with
+// its summary as description and categories as tags.
+func ParseAtom(r io.Reader) ([]Bookmark, error) {
+	var feed atomFeed
+	if err := xml.NewDecoder(r).Decode(&feed); err != nil {
+		return nil, err
+	}
+
+	result := make([]Bookmark, 0, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		bookmark := Bookmark{Url: entry.Link.Href, Description: entry.Summary}
+		if entry.Published != "" {
+			createdAt, err := time.Parse(time.RFC3339, entry.Published)
+			if err != nil {
+				return nil, fmt.Errorf("invalid published time %q", entry.Published)
+			}
+			bookmark.CreatedAt = createdAt.UTC()
+		}
+		for _, category := range entry.Categories {
+			bookmark.Tags = appendTag(bookmark.Tags, category.Term)
+		}
+		result = append(result, bookmark)
+	}
+	return result, nil
+}
+
+type atomWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+	updated time.Time
+}
+
+// NewAtomWriter writes the feed header right away, so updated is the export time
+// rather than the time of the latest entry.
+func NewAtomWriter(w io.Writer, updated time.Time) (Writer, error) {
+	header := xml.Header + `<feed xmlns="` + atomNamespace + `">` + "\n" +
+		"  <title>Links</title>\n" +
+		"  <id>urn:ova-link-api:export</id>\n" +
+		"  <author><name>ova-link-api</name></author>\n" +
+		"  <updated>" + updated.UTC().Format(time.RFC3339) + "</updated>\n"
+	if _, err := io.WriteString(w, header); err != nil {
+		return nil, err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("  ", "  ")
+	return &atomWriter{w: w, encoder: encoder, updated: updated}, nil
+}
+
+func (aw *atomWriter) Write(bookmark Bookmark) error {
+	entry := atomEntry{
+		ID:      bookmark.Url,
+		Title:   bookmark.Description,
+		Link:    atomLink{Href: bookmark.Url},
+		Updated: aw.updated.UTC().Format(time.RFC3339),
+		Summary: bookmark.Description,
+	}
+	if entry.Title == "" {
+		entry.Title = bookmark.Url
+	}
+	if !bookmark.CreatedAt.IsZero() {
+		entry.Published = bookmark.CreatedAt.UTC().Format(time.RFC3339Nano)
+		entry.Updated = entry.Published
+	}
+	for _, tag := range bookmark.Tags {
+		entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+	}
+
+	if err := aw.encoder.Encode(entry); err != nil {
+		return err
+	}
+	_, err := io.WriteString(aw.w, "\n")
+	return err
+}
+
+func (aw *atomWriter) Close() error {
+	_, err := io.WriteString(aw.w, "</feed>\n")
+	return err
+}
@@ -0,0 +1,169 @@
+package bookmarks
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Format int
+
+const (
+	FormatAuto Format = iota
+	FormatNetscape
+	FormatCSV
+	FormatJSON
+	FormatAtom
+)
+
+const detectLength = 512
+
+var ErrUnknownFormat = errors.New("unknown bookmarks format")
+
+type Writer interface {
+	Write(bookmark Bookmark) error
+	// Close writes the end of the document, it does not close the underlying writer.
+	Close() error
+}
+
+type Bookmark struct {
+	Url         string    `json:"url"`
+	Description string    `json:"description,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// Detect guesses the format by the first meaningful bytes of the file.
+func Detect(data []byte) Format {
+	data = bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	head := data
+	if len(head) > detectLength {
+		head = head[:detectLength]
+	}
+
+	switch {
+	case len(data) == 0:
+		return FormatAuto
+	case data[0] == '<' && bytes.Contains(head, []byte("<feed")):
+		return FormatAtom
+	case data[0] == '<':
+		return FormatNetscape
+	case data[0] == '[' || data[0] == '{':
+		return FormatJSON
+	default:
+		return FormatCSV
+	}
+}
+
+func Parse(format Format, r io.Reader) ([]Bookmark, error) {
+	if format == FormatAuto {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		format = Detect(data)
+		r = bytes.NewReader(data)
+	}
+
+	switch format {
+	case FormatNetscape:
+		return ParseNetscape(r)
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatJSON:
+		return ParseJSON(r)
+	case FormatAtom:
+		return ParseAtom(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatNetscape:
+		return NewNetscapeWriter(w)
+	case FormatCSV:
+		return NewCSVWriter(w)
+	case FormatJSON:
+		return NewJSONWriter(w), nil
+	case FormatAtom:
+		return NewAtomWriter(w, time.Now())
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// parseUnixTime accepts seconds as well as the milli- and microseconds some browsers write.
+func parseUnixTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+	}
+	switch {
+	case timestamp > 1e14:
+		return time.Unix(0, timestamp*int64(time.Microsecond)).UTC(), nil
+	case timestamp > 1e11:
+		return time.Unix(0, timestamp*int64(time.Millisecond)).UTC(), nil
+	case timestamp > 0:
+		return time.Unix(timestamp, 0).UTC(), nil
+	default:
+		return time.Time{}, nil
+	}
+}
+
+// tagSeparators split tags in the CSV and Netscape formats. A backslash escapes them and itself inside a tag.
+const tagSeparators = ",|"
+
+var tagEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, "|", `\|`)
+
+func joinTags(tags []string, separator string) string {
+	escaped := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		escaped = append(escaped, tagEscaper.Replace(tag))
+	}
+	return strings.Join(escaped, separator)
+}
+
+// splitTags splits value on the unescaped separators. A backslash before anything else is kept,
+// so tags of other exports that happen to contain one are read as they are.
+func splitTags(value string, separators string) []string {
+	var tags []string
+	var tag strings.Builder
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '\\' || strings.ContainsRune(tagSeparators, runes[i+1])):
+			i++
+			tag.WriteRune(runes[i])
+		case strings.ContainsRune(separators, runes[i]):
+			tags = append(tags, tag.String())
+			tag.Reset()
+		default:
+			tag.WriteRune(runes[i])
+		}
+	}
+	return append(tags, tag.String())
+}
+
+func appendTag(tags []string, tag string) []string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return tags
+	}
+	for _, existing := range tags {
+		if existing == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
@@ -0,0 +1,13 @@
+package bookmarks_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBookmarks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Bookmarks Suite")
+}
@@ -0,0 +1,168 @@
+package bookmarks_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/bookmarks"
+)
+
+const netscapeFile = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1600000000" PERSONAL_TOOLBAR_FOLDER="true">Bookmarks bar</H3>
+    <DL><p>
+        <DT><A HREF="https://golang.org/" ADD_DATE="1600000100">The Go
+            Programming Language</A>
+        <DT><H3>Dev</H3>
+        <DD>Folder description
+        <DL><p>
+            <DT><H3>Databases</H3>
+            <DL><p>
+                <DT><A HREF="https://www.postgresql.org/" ADD_DATE="1600000200000" TAGS="sql,postgres">PostgreSQL</A>
+                <DD>The world's most advanced open source database
+            </DL><p>
+            <DT><A HREF="https://grpc.io/">gRPC</A>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="https://news.ycombinator.com/">Hacker News</A>
+</DL><p>
+`
+
+var _ = Describe("Bookmarks", func() {
+	It("Parse Netscape bookmark file", func() {
+		result, err := bookmarks.Parse(bookmarks.FormatAuto, strings.NewReader(netscapeFile))
+
+		Expect(err).Should(Succeed())
+		Expect(result).Should(Equal([]bookmarks.Bookmark{
+			{
+				Url:         "https://golang.org/",
+				Description: "The Go Programming Language",
+				CreatedAt:   time.Unix(1600000100, 0).UTC(),
+			},
+			{
+				Url:         "https://www.postgresql.org/",
+				Description: "The world's most advanced open source database",
+				Tags:        []string{"Dev", "Databases", "sql", "postgres"},
+				CreatedAt:   time.Unix(1600000200, 0).UTC(),
+			},
+			{Url: "https://grpc.io/", Description: "gRPC", Tags: []string{"Dev"}},
+			{Url: "https://news.ycombinator.com/", Description: "Hacker News"},
+		}))
+	})
+
+	It("Parse Pocket CSV", func() {
+		file := "title,url,time_added,tags,status\n" +
+			"Go,https://golang.org/,1600000100,go|lang,unread\n" +
+			"\"Hello, world\",https://example.com/,,,archive\n"
+
+		result, err := bookmarks.Parse(bookmarks.FormatCSV, strings.NewReader(file))
+
+		Expect(err).Should(Succeed())
+		Expect(result).Should(Equal([]bookmarks.Bookmark{
+			{Url: "https://golang.org/", Description: "Go", Tags: []string{"go", "lang"}, CreatedAt: time.Unix(1600000100, 0).UTC()},
+			{Url: "https://example.com/", Description: "Hello, world"},
+		}))
+	})
+
+	It("Parse Raindrop CSV", func() {
+		file := "id,title,note,excerpt,url,folder,tags,created,cover,highlights,favorite\n" +
+			"1,Go,My note,Excerpt,https://golang.org/,Dev,\"go, lang\",2020-09-13T12:26:40.000Z,,,false\n" +
+			"2,Example,,,https://example.com/,Unsorted,,2020-09-13T12:26:40.000Z,,,false\n"
+
+		result, err := bookmarks.Parse(bookmarks.FormatAuto, strings.NewReader(file))
+
+		Expect(err).Should(Succeed())
+		Expect(result).Should(Equal([]bookmarks.Bookmark{
+			{Url: "https://golang.org/", Description: "My note", Tags: []string{"Dev", "go", "lang"}, CreatedAt: time.Unix(1600000000, 0).UTC()},
+			{Url: "https://example.com/", Description: "Example", CreatedAt: time.Unix(1600000000, 0).UTC()},
+		}))
+	})
+
+	It("Reject CSV without url column", func() {
+		_, err := bookmarks.Parse(bookmarks.FormatCSV, strings.NewReader("title,link\nGo,https://golang.org/\n"))
+
+		Expect(err).Should(HaveOccurred())
+	})
+
+	DescribeTable("Parse JSON",
+		func(file string) {
+			result, err := bookmarks.Parse(bookmarks.FormatAuto, strings.NewReader(file))
+
+			Expect(err).Should(Succeed())
+			Expect(result).Should(Equal([]bookmarks.Bookmark{
+				{Url: "https://golang.org/", Description: "Go", Tags: []string{"go"}, CreatedAt: time.Unix(1600000000, 0).UTC()},
+				{Url: "https://example.com/"},
+			}))
+		},
+		Entry("array", `[
+			{"url": "https://golang.org/", "description": "Go", "tags": ["go"], "created_at": "2020-09-13T12:26:40Z"},
+			{"url": "https://example.com/"}
+		]`),
+		Entry("lines", `{"url": "https://golang.org/", "description": "Go", "tags": ["go"], "created_at": "2020-09-13T12:26:40Z"}
+			{"url": "https://example.com/"}`),
+	)
+
+	It("Parse CSV tags with a backslash of another export. Should keep the backslash.", func() {
+		result, err := bookmarks.ParseCSV(strings.NewReader("title,url,time_added,tags\nGo,https://golang.org/,,\"c\\d|a\\,b\"\n"))
+
+		Expect(err).Should(Succeed())
+		Expect(result).Should(HaveLen(1))
+		Expect(result[0].Tags).Should(Equal([]string{`c\d`, "a,b"}))
+	})
+
+	It("Reject malformed JSON", func() {
+		_, err := bookmarks.Parse(bookmarks.FormatJSON, strings.NewReader(`[{"url": `))
+
+		Expect(err).Should(HaveOccurred())
+	})
+
+	DescribeTable("Detect format",
+		func(file string, format bookmarks.Format) {
+			Expect(bookmarks.Detect([]byte(file))).Should(Equal(format))
+		},
+		Entry("netscape", "\xef\xbb\xbf<!DOCTYPE NETSCAPE-Bookmark-file-1>", bookmarks.FormatNetscape),
+		Entry("json array", "  [", bookmarks.FormatJSON),
+		Entry("json lines", "{\"url\": \"\"}", bookmarks.FormatJSON),
+		Entry("atom", `<?xml version="1.0" encoding="UTF-8"?><feed xmlns="http://www.w3.org/2005/Atom">`, bookmarks.FormatAtom),
+		Entry("csv", "title,url", bookmarks.FormatCSV),
+		Entry("empty", " \n", bookmarks.FormatAuto),
+	)
+
+	DescribeTable("Round trip",
+		func(format bookmarks.Format) {
+			expected := []bookmarks.Bookmark{
+				{
+					Url:         "https://golang.org/?a=1&b=2",
+					Description: `The "Go" <Programming> Language`,
+					Tags:        []string{"go", "lang", "a,b", "c|d", `back\slash\`},
+					CreatedAt:   time.Unix(1600000000, 0).UTC(),
+				},
+				{Url: "https://example.com/"},
+			}
+
+			var buffer bytes.Buffer
+			writer, err := bookmarks.NewWriter(format, &buffer)
+			Expect(err).Should(Succeed())
+			for _, bookmark := range expected {
+				Expect(writer.Write(bookmark)).Should(Succeed())
+			}
+			Expect(writer.Close()).Should(Succeed())
+
+			Expect(bookmarks.Detect(buffer.Bytes())).Should(Equal(format))
+			result, err := bookmarks.Parse(bookmarks.FormatAuto, &buffer)
+			Expect(err).Should(Succeed())
+			Expect(result).Should(Equal(expected))
+		},
+		Entry("netscape", bookmarks.FormatNetscape),
+		Entry("csv", bookmarks.FormatCSV),
+		Entry("json lines", bookmarks.FormatJSON),
+		Entry("atom", bookmarks.FormatAtom),
+	)
+})
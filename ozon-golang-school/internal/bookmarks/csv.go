@@ -0,0 +1,123 @@
+package bookmarks
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Raindrop puts links that are not sorted into any collection into this folder.
+const unsortedFolder = "Unsorted"
+
+// ParseCSV reads Pocket (title, url, time_added, tags) and Raindrop
+// (title, note, url, folder, tags, created) exports. Columns are matched by header.
+func ParseCSV(r io.Reader) ([]Bookmark, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["url"]; !ok {
+		return nil, errors.New("csv header has no url column")
+	}
+
+	var result []Bookmark
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		bookmark := Bookmark{
+			Url:         field("url"),
+			Description: firstNonEmpty(field("note"), field("title")),
+		}
+		if folder := field("folder"); folder != unsortedFolder {
+			bookmark.Tags = appendTag(bookmark.Tags, folder)
+		}
+		for _, tag := range splitTags(field("tags"), tagSeparators) {
+			bookmark.Tags = appendTag(bookmark.Tags, tag)
+		}
+
+		bookmark.CreatedAt, err = parseCSVTime(field("time_added"), field("created"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		result = append(result, bookmark)
+	}
+}
+
+func parseCSVTime(unixTime string, isoTime string) (time.Time, error) {
+	if isoTime == "" {
+		return parseUnixTime(unixTime)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, isoTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q", isoTime)
+	}
+	return createdAt.UTC(), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+var pocketHeader = []string{"title", "url", "time_added", "tags"}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter writes links in the Pocket export layout, time_added holds the creation time in whole seconds.
+func NewCSVWriter(w io.Writer) (Writer, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(pocketHeader); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(bookmark Bookmark) error {
+	var timeAdded string
+	if !bookmark.CreatedAt.IsZero() {
+		timeAdded = strconv.FormatInt(bookmark.CreatedAt.Unix(), 10)
+	}
+	return cw.w.Write([]string{bookmark.Description, bookmark.Url, timeAdded, joinTags(bookmark.Tags, "|")})
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
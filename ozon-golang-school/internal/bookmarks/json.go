@@ -0,0 +1,72 @@
+package bookmarks
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// ParseJSON reads either an array of bookmarks or a stream of bookmark objects, one per line.
+func ParseJSON(r io.Reader) ([]Bookmark, error) {
+	buffered := bufio.NewReader(r)
+	decoder := json.NewDecoder(buffered)
+
+	first, err := firstByte(buffered)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Bookmark
+	if first == '[' {
+		if err := decoder.Decode(&result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	for {
+		var bookmark Bookmark
+		err := decoder.Decode(&bookmark)
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, bookmark)
+	}
+}
+
+func firstByte(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, r.UnreadByte()
+	}
+}
+
+type jsonWriter struct {
+	encoder *json.Encoder
+}
+
+// NewJSONWriter writes JSON Lines, one bookmark object per line.
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{encoder: json.NewEncoder(w)}
+}
+
+func (jw *jsonWriter) Write(bookmark Bookmark) error {
+	return jw.encoder.Encode(bookmark)
+}
+
+func (jw *jsonWriter) Close() error {
+	return nil
+}
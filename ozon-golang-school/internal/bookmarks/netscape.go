@@ -0,0 +1,148 @@
+package bookmarks
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ParseNetscape reads the bookmark file written by browsers. Every folder a bookmark
+// is nested in becomes a tag, except the browser toolbar folder.
+func ParseNetscape(r io.Reader) ([]Bookmark, error) {
+	var result []Bookmark
+	var folders []string
+	var folder string
+	var text *strings.Builder
+	var textTarget *string
+	lastBookmark := -1
+
+	tokenizer := html.NewTokenizer(r)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return nil, err
+			}
+			return result, nil
+		case html.TextToken:
+			if text != nil {
+				text.Write(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.H3, atom.A:
+				flushText(&text, textTarget)
+			case atom.Dl:
+				if len(folders) > 0 {
+					folders = folders[:len(folders)-1]
+				}
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[string(key)] = string(value)
+			}
+
+			// The description after <DD> has no closing tag and ends with the next element.
+			flushText(&text, textTarget)
+			switch atom.Lookup(name) {
+			case atom.H3:
+				folder, lastBookmark = "", -1
+				if attrs["personal_toolbar_folder"] != "true" {
+					text, textTarget = &strings.Builder{}, &folder
+				}
+			case atom.Dl:
+				folders = append(folders, folder)
+				folder = ""
+			case atom.A:
+				bookmark, err := newNetscapeBookmark(attrs, folders)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, bookmark)
+				lastBookmark = len(result) - 1
+				text, textTarget = &strings.Builder{}, &result[lastBookmark].Description
+			case atom.Dd:
+				if lastBookmark >= 0 {
+					text, textTarget = &strings.Builder{}, &result[lastBookmark].Description
+				}
+			}
+		}
+	}
+}
+
+func newNetscapeBookmark(attrs map[string]string, folders []string) (Bookmark, error) {
+	createdAt, err := parseUnixTime(attrs["add_date"])
+	if err != nil {
+		return Bookmark{}, err
+	}
+
+	bookmark := Bookmark{Url: strings.TrimSpace(attrs["href"]), CreatedAt: createdAt}
+	for _, folder := range folders {
+		bookmark.Tags = appendTag(bookmark.Tags, folder)
+	}
+	for _, tag := range splitTags(attrs["tags"], ",") {
+		bookmark.Tags = appendTag(bookmark.Tags, tag)
+	}
+	return bookmark, nil
+}
+
+func flushText(text **strings.Builder, target *string) {
+	if *text == nil {
+		return
+	}
+	if value := strings.Join(strings.Fields((*text).String()), " "); value != "" {
+		*target = value
+	}
+	*text = nil
+}
+
+const netscapeHeader = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+`
+
+type netscapeWriter struct {
+	w io.Writer
+}
+
+// NewNetscapeWriter writes a flat bookmark file, tags are kept in the TAGS attribute.
+// ADD_DATE holds the creation time in whole seconds.
+func NewNetscapeWriter(w io.Writer) (Writer, error) {
+	if _, err := io.WriteString(w, netscapeHeader); err != nil {
+		return nil, err
+	}
+	return &netscapeWriter{w: w}, nil
+}
+
+func (nw *netscapeWriter) Write(bookmark Bookmark) error {
+	var line strings.Builder
+	line.WriteString(`    <DT><A HREF="` + html.EscapeString(bookmark.Url) + `"`)
+	if !bookmark.CreatedAt.IsZero() {
+		line.WriteString(` ADD_DATE="` + strconv.FormatInt(bookmark.CreatedAt.Unix(), 10) + `"`)
+	}
+	if len(bookmark.Tags) > 0 {
+		line.WriteString(` TAGS="` + html.EscapeString(joinTags(bookmark.Tags, ",")) + `"`)
+	}
+	line.WriteString(">" + html.EscapeString(bookmark.Description) + "</A>\n")
+
+	_, err := io.WriteString(nw.w, line.String())
+	return err
+}
+
+func (nw *netscapeWriter) Close() error {
+	_, err := io.WriteString(nw.w, "</DL><p>\n")
+	return err
+}
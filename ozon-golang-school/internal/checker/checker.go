@@ -0,0 +1,154 @@
+package checker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"github.com/rs/zerolog"
+)
+
+type Checker interface {
+	Close()
+}
+
+type checker struct {
+	repo      repo.Repo
+	prober    Prober
+	logger    zerolog.Logger
+	workers   uint
+	batchSize uint64
+	interval  time.Duration
+	period    time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan bool
+	closer    sync.Once
+}
+
+// NewChecker starts a worker that checks the links not checked for period right away and then every interval,
+// so a restart does not check again the links checked shortly before it.
+// Links are walked in batches of batchSize and probed by at most workers goroutines.
+func NewChecker(
+	repo repo.Repo,
+	prober Prober,
+	logger zerolog.Logger,
+	workers uint,
+	batchSize uint64,
+	interval time.Duration,
+	period time.Duration,
+) Checker {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &checker{
+		repo:      repo,
+		prober:    prober,
+		logger:    logger,
+		workers:   workers,
+		batchSize: batchSize,
+		interval:  interval,
+		period:    period,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan bool),
+	}
+
+	c.startWorker()
+	return c
+}
+
+func (c *checker) Close() {
+	c.closer.Do(func() {
+		c.cancel()
+		<-c.done
+	})
+}
+
+func (c *checker) startWorker() {
+	go func(c *checker) {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for c.ctx.Err() == nil {
+			c.checkAll()
+
+			select {
+			case <-ticker.C:
+			case <-c.ctx.Done():
+			}
+		}
+		close(c.done)
+	}(c)
+}
+
+func (c *checker) checkAll() {
+	checkedBefore := time.Now().Add(-c.period)
+	var lastID uint64
+	for c.ctx.Err() == nil {
+		entities, err := c.repo.ListEntitiesToCheck(c.ctx, checkedBefore, lastID, c.batchSize)
+		if err != nil {
+			c.logger.Error().Err(err).Msg("failed to list links to check")
+			return
+		}
+
+		checks := c.checkBatch(entities)
+		if err := c.repo.AddChecks(c.ctx, checks); err != nil {
+			c.logger.Error().Err(err).Msg("failed to save link checks")
+		}
+
+		if uint64(len(entities)) < c.batchSize {
+			return
+		}
+		lastID = entities[len(entities)-1].ID
+	}
+}
+
+func (c *checker) checkBatch(entities []link.Link) []link.Check {
+	jobs := make(chan link.Link)
+	results := make(chan link.Check)
+	wg := sync.WaitGroup{}
+	for i := uint(0); i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entity := range jobs {
+				if check, ok := c.check(entity); ok {
+					results <- check
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, entity := range entities {
+			select {
+			case jobs <- entity:
+			case <-c.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	checks := make([]link.Check, 0, len(entities))
+	for check := range results {
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func (c *checker) check(entity link.Link) (link.Check, bool) {
+	check, err := c.prober.Probe(c.ctx, entity.Url)
+	if err != nil {
+		return check, false
+	}
+
+	check.LinkID = entity.ID
+	return check, true
+}
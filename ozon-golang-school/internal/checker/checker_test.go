@@ -0,0 +1,159 @@
+package checker_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/checker"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/rs/zerolog"
+)
+
+type proberFunc func(ctx context.Context, url string) (link.Check, error)
+
+func (f proberFunc) Probe(ctx context.Context, url string) (link.Check, error) {
+	return f(ctx, url)
+}
+
+var _ = Describe("Checker", func() {
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockRepo
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepo(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("Check the links due in batches", func() {
+		firstBatch := []link.Link{
+			{ID: 1, Url: "https://test.com/1"},
+			{ID: 2, Url: "https://test.com/2"},
+		}
+		secondBatch := []link.Link{
+			{ID: 3, Url: "https://test.com/3"},
+		}
+		var checkedBefore time.Time
+
+		saved := make(chan []link.Check, 2)
+		gomock.InOrder(
+			mockRepo.EXPECT().ListEntitiesToCheck(gomock.Any(), gomock.Any(), gomock.Eq(uint64(0)), gomock.Eq(uint64(2))).
+				DoAndReturn(func(ctx context.Context, before time.Time, afterID uint64, limit uint64) ([]link.Link, error) {
+					checkedBefore = before
+					return firstBatch, nil
+				}),
+			mockRepo.EXPECT().AddChecks(gomock.Any(), gomock.Len(2)).
+				DoAndReturn(func(ctx context.Context, checks []link.Check) error {
+					saved <- checks
+					return nil
+				}),
+			mockRepo.EXPECT().ListEntitiesToCheck(gomock.Any(), gomock.Any(), gomock.Eq(uint64(2)), gomock.Eq(uint64(2))).
+				DoAndReturn(func(ctx context.Context, before time.Time, afterID uint64, limit uint64) ([]link.Link, error) {
+					Expect(before).Should(Equal(checkedBefore))
+					return secondBatch, nil
+				}),
+			mockRepo.EXPECT().AddChecks(gomock.Any(), gomock.Len(1)).
+				DoAndReturn(func(ctx context.Context, checks []link.Check) error {
+					saved <- checks
+					return nil
+				}),
+		)
+
+		prober := proberFunc(func(ctx context.Context, url string) (link.Check, error) {
+			return link.Check{StatusCode: 200, FinalUrl: url}, nil
+		})
+		start := time.Now()
+		c := checker.NewChecker(mockRepo, prober, zerolog.Nop(), 2, 2, time.Hour, 24*time.Hour)
+
+		first := <-saved
+		second := <-saved
+		c.Close()
+
+		Expect(checkedBefore).Should(BeTemporally("~", start.Add(-24*time.Hour), time.Second))
+		Expect(first).Should(ConsistOf(
+			link.Check{LinkID: 1, StatusCode: 200, FinalUrl: "https://test.com/1"},
+			link.Check{LinkID: 2, StatusCode: 200, FinalUrl: "https://test.com/2"},
+		))
+		Expect(second).Should(ConsistOf(link.Check{LinkID: 3, StatusCode: 200, FinalUrl: "https://test.com/3"}))
+	})
+
+	It("Look for links due every interval", func() {
+		passes := make(chan bool, 3)
+		mockRepo.EXPECT().ListEntitiesToCheck(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, checkedBefore time.Time, afterID uint64, limit uint64) ([]link.Link, error) {
+				passes <- true
+				return []link.Link{}, nil
+			}).MinTimes(2)
+		mockRepo.EXPECT().AddChecks(gomock.Any(), gomock.Len(0)).Return(nil).AnyTimes()
+
+		prober := proberFunc(func(ctx context.Context, url string) (link.Check, error) {
+			return link.Check{}, nil
+		})
+		c := checker.NewChecker(mockRepo, prober, zerolog.Nop(), 1, 10, 10*time.Millisecond, time.Hour)
+
+		<-passes
+		<-passes
+		c.Close()
+	})
+
+	It("Close stops checks in progress", func() {
+		started := make(chan bool)
+		once := sync.Once{}
+		mockRepo.EXPECT().ListEntitiesToCheck(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]link.Link{{ID: 1, Url: "https://test.com"}}, nil)
+		mockRepo.EXPECT().AddChecks(gomock.Any(), gomock.Len(0)).Return(nil).MaxTimes(1)
+
+		prober := proberFunc(func(ctx context.Context, url string) (link.Check, error) {
+			once.Do(func() { close(started) })
+			<-ctx.Done()
+			return link.Check{}, ctx.Err()
+		})
+		c := checker.NewChecker(mockRepo, prober, zerolog.Nop(), 1, 10, time.Hour, 24*time.Hour)
+
+		Eventually(started).Should(BeClosed())
+		c.Close()
+	})
+
+	It("Check many links of one host. Should wait for the rate limit beyond the request timeout.", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		entities := make([]link.Link, 0, 20)
+		for i := 1; i <= 20; i++ {
+			entities = append(entities, link.Link{ID: uint64(i), Url: server.URL + "/" + strconv.Itoa(i)})
+		}
+		saved := make(chan []link.Check, 1)
+		mockRepo.EXPECT().ListEntitiesToCheck(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(entities, nil)
+		mockRepo.EXPECT().AddChecks(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, checks []link.Check) error {
+				saved <- checks
+				return nil
+			})
+
+		client := *server.Client()
+		client.Timeout = 50 * time.Millisecond
+		prober := checker.NewHTTPProber(&client, 10*time.Millisecond, 5)
+		c := checker.NewChecker(mockRepo, prober, zerolog.Nop(), 8, 100, time.Hour, 24*time.Hour)
+
+		var checks []link.Check
+		Eventually(saved, 5*time.Second).Should(Receive(&checks))
+		c.Close()
+
+		Expect(checks).Should(HaveLen(20))
+		for _, check := range checks {
+			Expect(check.Error).Should(BeEmpty())
+			Expect(check.StatusCode).Should(Equal(http.StatusOK))
+		}
+	})
+})
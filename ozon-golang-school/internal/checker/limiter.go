@@ -0,0 +1,61 @@
+package checker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const maxTrackedHosts = 1024
+
+type hostLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     map[string]time.Time
+}
+
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// Wait blocks until a request to host is allowed: requests to the same host are spaced by interval.
+// A slot is taken only when the request is allowed, so a wait cancelled by ctx holds none.
+func (l *hostLimiter) Wait(ctx context.Context, host string) error {
+	for {
+		delay := l.take(host)
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// take takes the slot of host when it is free, otherwise it returns the time left until it frees.
+func (l *hostLimiter) take(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if len(l.next) >= maxTrackedHosts {
+		for tracked, at := range l.next {
+			if at.Before(now) {
+				delete(l.next, tracked)
+			}
+		}
+	}
+	if at, ok := l.next[host]; ok && at.After(now) {
+		return at.Sub(now)
+	}
+	l.next[host] = now.Add(l.interval)
+	return 0
+}
@@ -0,0 +1,105 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+type Prober interface {
+	// Probe returns an error when the link could not be probed because ctx is done,
+	// the check says nothing about the link then and should not be saved.
+	Probe(ctx context.Context, url string) (link.Check, error)
+}
+
+type httpProber struct {
+	client       *http.Client
+	limiter      *hostLimiter
+	maxRedirects int
+}
+
+// NewHTTPProber checks links with HEAD requests falling back to GET for servers that reject HEAD.
+// Redirects are followed up to maxRedirects, every request waits for the per-host rate limit.
+// The timeout of client applies to every request alone, waits for the rate limit are not counted.
+// client should be netguard.NewClient to keep the checks from reaching the internal network.
+func NewHTTPProber(client *http.Client, hostInterval time.Duration, maxRedirects int) Prober {
+	single := *client
+	single.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return &httpProber{
+		client:       &single,
+		limiter:      newHostLimiter(hostInterval),
+		maxRedirects: maxRedirects,
+	}
+}
+
+func (p *httpProber) Probe(ctx context.Context, url string) (link.Check, error) {
+	check := link.Check{FinalUrl: url}
+
+	resp, err := p.follow(ctx, http.MethodHead, url)
+	if ctx.Err() == nil && (err != nil || resp.StatusCode >= 400) {
+		resp, err = p.follow(ctx, http.MethodGet, url)
+	}
+	if ctx.Err() != nil {
+		return check, ctx.Err()
+	}
+	check.CheckedAt = time.Now()
+	if err != nil {
+		check.Error = err.Error()
+		return check, nil
+	}
+
+	check.StatusCode = resp.StatusCode
+	check.FinalUrl = resp.Request.URL.String()
+	return check, nil
+}
+
+// follow requests url and the locations it redirects to, each of them waits for the rate limit of its host.
+func (p *httpProber) follow(ctx context.Context, method string, url string) (*http.Response, error) {
+	for redirects := 0; ; redirects++ {
+		resp, err := p.request(ctx, method, url)
+		if err != nil {
+			return nil, err
+		}
+		location, err := resp.Location()
+		if !isRedirect(resp.StatusCode) || err != nil {
+			return resp, nil
+		}
+		if redirects >= p.maxRedirects {
+			return nil, fmt.Errorf("stopped after %d redirects", p.maxRedirects)
+		}
+		url = location.String()
+	}
+}
+
+func (p *httpProber) request(ctx context.Context, method string, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.limiter.Wait(ctx, req.URL.Host); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+func isRedirect(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
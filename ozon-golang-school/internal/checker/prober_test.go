@@ -0,0 +1,179 @@
+package checker_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/checker"
+	"github.com/ozonva/ova-link-api/internal/netguard"
+)
+
+var _ = Describe("HTTP prober", func() {
+	var server *httptest.Server
+	var headRequests int32
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&headRequests, 0)
+		mux := http.NewServeMux()
+		mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				atomic.AddInt32(&headRequests, 1)
+			}
+		})
+		mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		})
+		mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {})
+		mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		})
+		mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		})
+		mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		})
+		mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		})
+		server = httptest.NewServer(mux)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Probe healthy link with HEAD", func() {
+		prober := checker.NewHTTPProber(server.Client(), 0, 5)
+
+		check, err := prober.Probe(context.Background(), server.URL+"/ok")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(check.StatusCode).Should(Equal(http.StatusOK))
+		Expect(check.Broken()).Should(BeFalse())
+		Expect(check.CheckedAt).ShouldNot(BeZero())
+		Expect(atomic.LoadInt32(&headRequests)).Should(Equal(int32(1)))
+	})
+
+	It("Probe missing link", func() {
+		prober := checker.NewHTTPProber(server.Client(), 0, 5)
+
+		check, err := prober.Probe(context.Background(), server.URL+"/missing")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(check.StatusCode).Should(Equal(http.StatusNotFound))
+		Expect(check.Broken()).Should(BeTrue())
+	})
+
+	It("Probe follows redirects", func() {
+		prober := checker.NewHTTPProber(server.Client(), 0, 5)
+
+		check, err := prober.Probe(context.Background(), server.URL+"/old")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(check.StatusCode).Should(Equal(http.StatusOK))
+		Expect(check.FinalUrl).Should(Equal(server.URL + "/new"))
+	})
+
+	It("Probe stops redirect loops", func() {
+		prober := checker.NewHTTPProber(server.Client(), 0, 5)
+
+		check, err := prober.Probe(context.Background(), server.URL+"/loop")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(check.Error).Should(ContainSubstring("stopped after 5 redirects"))
+		Expect(check.Broken()).Should(BeTrue())
+	})
+
+	It("Probe falls back to GET", func() {
+		prober := checker.NewHTTPProber(server.Client(), 0, 5)
+
+		check, err := prober.Probe(context.Background(), server.URL+"/no-head")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(check.StatusCode).Should(Equal(http.StatusOK))
+	})
+
+	It("Probe with request timeout", func() {
+		client := *server.Client()
+		client.Timeout = 50 * time.Millisecond
+		prober := checker.NewHTTPProber(&client, 0, 5)
+
+		check, err := prober.Probe(context.Background(), server.URL+"/slow")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(check.Error).Should(ContainSubstring("Client.Timeout exceeded"))
+		Expect(check.Broken()).Should(BeTrue())
+	})
+
+	It("Probe with done context. Should return the error of the context and no check.", func() {
+		prober := checker.NewHTTPProber(server.Client(), 0, 5)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := prober.Probe(ctx, server.URL+"/slow")
+
+		Expect(err).Should(MatchError(context.DeadlineExceeded))
+	})
+
+	It("Probe same host with rate limit while waits are cancelled. Should not count the cancelled waits.", func() {
+		prober := checker.NewHTTPProber(server.Client(), 200*time.Millisecond, 5)
+		start := time.Now()
+		_, err := prober.Probe(context.Background(), server.URL+"/ok")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		for i := 0; i < 5; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			_, err := prober.Probe(ctx, server.URL+"/ok")
+			cancel()
+			Expect(err).Should(MatchError(context.DeadlineExceeded))
+		}
+
+		time.Sleep(200*time.Millisecond - time.Since(start))
+		probed := time.Now()
+		_, err = prober.Probe(context.Background(), server.URL+"/ok")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(time.Since(probed)).Should(BeNumerically("<", 100*time.Millisecond))
+	})
+
+	It("Probe same host with rate limit", func() {
+		prober := checker.NewHTTPProber(server.Client(), 100*time.Millisecond, 5)
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			prober.Probe(context.Background(), server.URL+"/ok")
+		}
+
+		Expect(time.Since(start)).Should(BeNumerically(">=", 200*time.Millisecond))
+	})
+	It("Probe link that redirects inside the network. Should refuse to follow.", func() {
+		// Only the test server is let through, as if it was a public one.
+		dialer := &net.Dialer{Control: func(network string, address string, c syscall.RawConn) error {
+			if address == server.Listener.Addr().String() {
+				return nil
+			}
+			return netguard.Control(network, address, c)
+		}}
+		client := netguard.NewClient(time.Second)
+		client.Transport.(*http.Transport).DialContext = dialer.DialContext
+		prober := checker.NewHTTPProber(client, 0, 5)
+
+		check, err := prober.Probe(context.Background(), server.URL+"/metadata")
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(check.Error).Should(ContainSubstring(netguard.ErrForbiddenAddress.Error()))
+		Expect(check.Broken()).Should(BeTrue())
+	})
+})
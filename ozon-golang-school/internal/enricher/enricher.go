@@ -0,0 +1,143 @@
+package enricher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"github.com/rs/zerolog"
+)
+
+type Enricher interface {
+	Close()
+}
+
+type enricher struct {
+	repo         repo.Repo
+	fetcher      Fetcher
+	logger       zerolog.Logger
+	workers      uint
+	batchSize    uint64
+	fetchTimeout time.Duration
+	ticker       *time.Ticker
+	ctx          context.Context
+	cancel       context.CancelFunc
+	done         chan bool
+	closer       sync.Once
+}
+
+// NewEnricher starts a worker that fills metadata of newly saved links every period.
+// Links are fetched by at most workers goroutines, each fetch is limited by fetchTimeout.
+// A link is fetched once: failed fetches store empty metadata.
+func NewEnricher(
+	repo repo.Repo,
+	fetcher Fetcher,
+	logger zerolog.Logger,
+	workers uint,
+	batchSize uint64,
+	fetchTimeout time.Duration,
+	period time.Duration,
+) Enricher {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &enricher{
+		repo:         repo,
+		fetcher:      fetcher,
+		logger:       logger,
+		workers:      workers,
+		batchSize:    batchSize,
+		fetchTimeout: fetchTimeout,
+		ticker:       time.NewTicker(period),
+		ctx:          ctx,
+		cancel:       cancel,
+		done:         make(chan bool),
+	}
+
+	e.startWorker()
+	return e
+}
+
+func (e *enricher) Close() {
+	e.closer.Do(func() {
+		e.cancel()
+		<-e.done
+	})
+}
+
+func (e *enricher) startWorker() {
+	go func(e *enricher) {
+	exit:
+		for {
+			select {
+			case <-e.ticker.C:
+				if e.ctx.Err() == nil {
+					e.enrichBatch()
+				}
+			case <-e.ctx.Done():
+				e.ticker.Stop()
+				break exit
+			}
+		}
+		close(e.done)
+	}(e)
+}
+
+func (e *enricher) enrichBatch() {
+	entities, err := e.repo.ListEntitiesToEnrich(e.ctx, e.batchSize)
+	if err != nil {
+		e.logger.Error().Err(err).Msg("failed to list links to enrich")
+		return
+	}
+
+	jobs := make(chan link.Link)
+	wg := sync.WaitGroup{}
+	for i := uint(0); i < e.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entity := range jobs {
+				e.enrich(entity)
+			}
+		}()
+	}
+
+	for _, entity := range entities {
+		select {
+		case jobs <- entity:
+		case <-e.ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func (e *enricher) enrich(entity link.Link) {
+	if e.ctx.Err() != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(e.ctx, e.fetchTimeout)
+	defer cancel()
+
+	metadata := link.Metadata{}
+	page, err := e.fetcher.Fetch(ctx, entity.Url)
+	switch {
+	case e.ctx.Err() != nil:
+		return
+	case err != nil:
+		e.logger.Warn().Err(err).Uint64("id", entity.ID).Str("url", entity.Url).Msg("failed to fetch link")
+	default:
+		metadata = ParseMetadata(page, entity.Url)
+	}
+
+	err = e.repo.UpdateEntityMetadata(e.ctx, entity.ID, entity.Url, metadata)
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		// The url changed while it was fetched, the link is listed to enrich again.
+		e.logger.Debug().Err(err).Uint64("id", entity.ID).Msg("dropped stale link metadata")
+	case err != nil:
+		e.logger.Error().Err(err).Uint64("id", entity.ID).Msg("failed to save link metadata")
+	}
+}
@@ -0,0 +1,13 @@
+package enricher_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestEnricher(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Enricher Suite")
+}
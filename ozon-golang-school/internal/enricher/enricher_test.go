@@ -0,0 +1,167 @@
+package enricher_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/enricher"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/rs/zerolog"
+)
+
+type fetcherFunc func(ctx context.Context, url string) ([]byte, error)
+
+func (f fetcherFunc) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+var _ = Describe("Enricher", func() {
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockRepo
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepo(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("Enrich new links", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`<html><head><title>Title of ` + r.URL.Path + `</title></head></html>`))
+		}))
+		defer server.Close()
+
+		entities := []link.Link{
+			{ID: 1, Url: server.URL + "/first"},
+			{ID: 2, Url: server.URL + "/second"},
+		}
+		updated := make(chan link.Metadata, 2)
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Eq(uint64(10))).Return(entities, nil).MinTimes(1)
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Eq(uint64(10))).Return([]link.Link{}, nil).AnyTimes()
+		mockRepo.EXPECT().UpdateEntityMetadata(gomock.Any(), gomock.Eq(uint64(1)), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id uint64, url string, metadata link.Metadata) error {
+				updated <- metadata
+				return nil
+			})
+		mockRepo.EXPECT().UpdateEntityMetadata(gomock.Any(), gomock.Eq(uint64(2)), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id uint64, url string, metadata link.Metadata) error {
+				updated <- metadata
+				return nil
+			})
+
+		e := enricher.NewEnricher(
+			mockRepo,
+			enricher.NewHTTPFetcher(server.Client(), 1024),
+			zerolog.Nop(),
+			2, 10, time.Second, 10*time.Millisecond,
+		)
+
+		titles := []string{(<-updated).Title, (<-updated).Title}
+		e.Close()
+
+		Expect(titles).Should(ConsistOf("Title of /first", "Title of /second"))
+	})
+
+	It("Store empty metadata when fetch fails", func() {
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Any()).Return([]link.Link{{ID: 1, Url: "https://test.com"}}, nil)
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Any()).Return([]link.Link{}, nil).AnyTimes()
+		done := make(chan bool)
+		mockRepo.EXPECT().UpdateEntityMetadata(gomock.Any(), gomock.Eq(uint64(1)), gomock.Any(), gomock.Eq(link.Metadata{})).
+			DoAndReturn(func(ctx context.Context, id uint64, url string, metadata link.Metadata) error {
+				close(done)
+				return nil
+			})
+
+		fetcher := fetcherFunc(func(ctx context.Context, url string) ([]byte, error) {
+			return nil, errors.New("connection refused")
+		})
+		e := enricher.NewEnricher(mockRepo, fetcher, zerolog.Nop(), 1, 10, time.Second, 10*time.Millisecond)
+
+		Eventually(done).Should(BeClosed())
+		e.Close()
+	})
+
+	It("Fetch with bounded concurrency", func() {
+		entities := make([]link.Link, 0, 12)
+		for i := 1; i <= 12; i++ {
+			entities = append(entities, link.Link{ID: uint64(i), Url: "https://test.com"})
+		}
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Any()).Return(entities, nil)
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Any()).Return([]link.Link{}, nil).AnyTimes()
+
+		var saved int32
+		mockRepo.EXPECT().UpdateEntityMetadata(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id uint64, url string, metadata link.Metadata) error {
+				atomic.AddInt32(&saved, 1)
+				return nil
+			}).Times(12)
+
+		var active, maxActive int32
+		fetcher := fetcherFunc(func(ctx context.Context, url string) ([]byte, error) {
+			current := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				observed := atomic.LoadInt32(&maxActive)
+				if current <= observed || atomic.CompareAndSwapInt32(&maxActive, observed, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return []byte("<title>Page</title>"), nil
+		})
+		e := enricher.NewEnricher(mockRepo, fetcher, zerolog.Nop(), 3, 12, time.Second, 10*time.Millisecond)
+
+		Eventually(func() int32 { return atomic.LoadInt32(&saved) }).Should(Equal(int32(12)))
+		e.Close()
+
+		Expect(atomic.LoadInt32(&maxActive)).Should(BeNumerically("<=", 3))
+	})
+
+	It("Close cancels fetches in progress", func() {
+		started := make(chan bool)
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Any()).Return([]link.Link{{ID: 1, Url: "https://test.com"}}, nil)
+		mockRepo.EXPECT().UpdateEntityMetadata(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		fetcher := fetcherFunc(func(ctx context.Context, url string) ([]byte, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		e := enricher.NewEnricher(mockRepo, fetcher, zerolog.Nop(), 1, 10, time.Minute, 10*time.Millisecond)
+
+		Eventually(started).Should(BeClosed())
+		e.Close()
+	})
+
+	It("Fetch with timeout", func() {
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Any()).Return([]link.Link{{ID: 1, Url: "https://test.com"}}, nil)
+		mockRepo.EXPECT().ListEntitiesToEnrich(gomock.Any(), gomock.Any()).Return([]link.Link{}, nil).AnyTimes()
+		done := make(chan bool)
+		mockRepo.EXPECT().UpdateEntityMetadata(gomock.Any(), gomock.Eq(uint64(1)), gomock.Any(), gomock.Eq(link.Metadata{})).
+			DoAndReturn(func(ctx context.Context, id uint64, url string, metadata link.Metadata) error {
+				close(done)
+				return nil
+			})
+
+		fetcher := fetcherFunc(func(ctx context.Context, url string) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		e := enricher.NewEnricher(mockRepo, fetcher, zerolog.Nop(), 1, 10, 20*time.Millisecond, 10*time.Millisecond)
+
+		Eventually(done).Should(BeClosed())
+		e.Close()
+	})
+})
@@ -0,0 +1,53 @@
+package enricher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+)
+
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+type httpFetcher struct {
+	client      *http.Client
+	maxBodySize int64
+}
+
+// NewHTTPFetcher fetches pages of user submitted URLs, client should be netguard.NewClient to keep
+// them from reaching the internal network.
+func NewHTTPFetcher(client *http.Client, maxBodySize int64) Fetcher {
+	return &httpFetcher{
+		client:      client,
+		maxBodySize: maxBodySize,
+	}
+}
+
+// Fetch returns at most maxBodySize bytes of the page, which is enough to read its head.
+func (f *httpFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil &&
+		mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("unexpected content type %q", mediaType)
+	}
+
+	return ioutil.ReadAll(io.LimitReader(resp.Body, f.maxBodySize))
+}
@@ -0,0 +1,102 @@
+package enricher_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/enricher"
+	"github.com/ozonva/ova-link-api/internal/netguard"
+)
+
+var _ = Describe("HTTP fetcher", func() {
+	var server *httptest.Server
+	var fetcher enricher.Fetcher
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html><head><title>Page</title></head></html>"))
+		})
+		mux.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(strings.Repeat("a", 1000)))
+		})
+		mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+		})
+		mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+		})
+		mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		})
+		server = httptest.NewServer(mux)
+		fetcher = enricher.NewHTTPFetcher(server.Client(), 100)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Fetch page", func() {
+		page, err := fetcher.Fetch(context.Background(), server.URL+"/page")
+
+		Expect(err).Should(Succeed())
+		Expect(string(page)).Should(ContainSubstring("<title>Page</title>"))
+	})
+
+	It("Fetch at most max body size", func() {
+		page, err := fetcher.Fetch(context.Background(), server.URL+"/large")
+
+		Expect(err).Should(Succeed())
+		Expect(page).Should(HaveLen(100))
+	})
+
+	It("Fetch not a page", func() {
+		_, err := fetcher.Fetch(context.Background(), server.URL+"/image")
+
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Fetch missing page", func() {
+		_, err := fetcher.Fetch(context.Background(), server.URL+"/missing")
+
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("Fetch with timeout", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := fetcher.Fetch(ctx, server.URL+"/slow")
+
+		Expect(err).Should(MatchError(ContainSubstring("context deadline exceeded")))
+	})
+	It("Fetch a page that redirects inside the network. Should refuse to follow.", func() {
+		// Only the test server is let through, as if it was a public one.
+		dialer := &net.Dialer{Control: func(network string, address string, c syscall.RawConn) error {
+			if address == server.Listener.Addr().String() {
+				return nil
+			}
+			return netguard.Control(network, address, c)
+		}}
+		client := netguard.NewClient(time.Second)
+		client.Transport.(*http.Transport).DialContext = dialer.DialContext
+
+		_, err := enricher.NewHTTPFetcher(client, 100).Fetch(context.Background(), server.URL+"/metadata")
+
+		Expect(err).Should(MatchError(netguard.ErrForbiddenAddress))
+	})
+})
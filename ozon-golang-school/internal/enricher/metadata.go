@@ -0,0 +1,106 @@
+package enricher
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ParseMetadata reads the page head. OpenGraph tags take precedence over Twitter
+// cards, which take precedence over the plain <title> and description.
+func ParseMetadata(page []byte, pageUrl string) link.Metadata {
+	base, _ := url.Parse(pageUrl)
+	meta := make(map[string]string)
+	var title, favicon, canonical string
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(page))
+	inTitle := false
+parse:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			break parse
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch atom.Lookup(name) {
+			case atom.Title:
+				inTitle = false
+			case atom.Head:
+				break parse
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[string(key)] = string(value)
+			}
+
+			switch atom.Lookup(name) {
+			case atom.Body:
+				break parse
+			case atom.Title:
+				inTitle = true
+			case atom.Meta:
+				key := strings.ToLower(attrs["property"])
+				if key == "" {
+					key = strings.ToLower(attrs["name"])
+				}
+				if _, ok := meta[key]; !ok && key != "" {
+					meta[key] = strings.TrimSpace(attrs["content"])
+				}
+			case atom.Link:
+				for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+					switch rel {
+					case "canonical":
+						if canonical == "" {
+							canonical = attrs["href"]
+						}
+					case "icon", "apple-touch-icon":
+						if favicon == "" {
+							favicon = attrs["href"]
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return link.Metadata{
+		Title:            firstNonEmpty(meta["og:title"], meta["twitter:title"], title),
+		PageDescription:  firstNonEmpty(meta["og:description"], meta["twitter:description"], meta["description"]),
+		ImageUrl:         resolve(base, firstNonEmpty(meta["og:image"], meta["twitter:image"])),
+		FaviconUrl:       resolve(base, favicon),
+		PageCanonicalUrl: resolve(base, firstNonEmpty(canonical, meta["og:url"])),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func resolve(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || base == nil {
+		return ref
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(parsed).String()
+}
@@ -0,0 +1,70 @@
+package enricher_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/enricher"
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+var _ = Describe("Metadata", func() {
+	It("Parse OpenGraph page", func() {
+		page := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Plain title</title>
+	<meta name="description" content="Plain description">
+	<meta property="og:title" content="OG title">
+	<meta property="og:description" content="OG description">
+	<meta property="og:image" content="/images/cover.png">
+	<meta name="twitter:title" content="Twitter title">
+	<link rel="shortcut icon" href="/favicon.png">
+	<link rel="canonical" href="https://example.com/article">
+</head>
+<body><title>Not a title</title></body>
+</html>`
+
+		metadata := enricher.ParseMetadata([]byte(page), "https://example.com/article?utm_source=mail")
+
+		Expect(metadata).Should(Equal(link.Metadata{
+			Title:            "OG title",
+			PageDescription:  "OG description",
+			ImageUrl:         "https://example.com/images/cover.png",
+			FaviconUrl:       "https://example.com/favicon.png",
+			PageCanonicalUrl: "https://example.com/article",
+		}))
+	})
+
+	It("Parse Twitter card", func() {
+		page := `<html><head>
+	<meta name="twitter:title" content="Twitter title">
+	<meta name="twitter:description" content="Twitter description">
+	<meta name="twitter:image" content="https://cdn.example.com/card.png">
+</head></html>`
+
+		metadata := enricher.ParseMetadata([]byte(page), "https://example.com/")
+
+		Expect(metadata.Title).Should(Equal("Twitter title"))
+		Expect(metadata.PageDescription).Should(Equal("Twitter description"))
+		Expect(metadata.ImageUrl).Should(Equal("https://cdn.example.com/card.png"))
+	})
+
+	It("Parse plain page", func() {
+		page := `<html><head><title> Plain &amp; simple </title><meta name="Description" content="About"></head></html>`
+
+		metadata := enricher.ParseMetadata([]byte(page), "https://example.com/")
+
+		Expect(metadata.Title).Should(Equal("Plain & simple"))
+		Expect(metadata.PageDescription).Should(Equal("About"))
+		Expect(metadata.FaviconUrl).Should(BeEmpty())
+		Expect(metadata.PageCanonicalUrl).Should(BeEmpty())
+	})
+
+	It("Parse truncated page", func() {
+		page := `<html><head><title>Cut`
+
+		metadata := enricher.ParseMetadata([]byte(page), "https://example.com/")
+
+		Expect(metadata.Title).Should(Equal("Cut"))
+	})
+})
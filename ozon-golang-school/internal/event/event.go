@@ -0,0 +1,57 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+type Type string
+
+const (
+	LinkCreated Type = "LinkCreated"
+	LinkUpdated Type = "LinkUpdated"
+	LinkDeleted Type = "LinkDeleted"
+	// LinkPurged follows LinkDeleted once the link is removed from the trash for good.
+	LinkPurged Type = "LinkPurged"
+)
+
+// Event is a row of the outbox table. Payload holds the JSON encoded LinkPayload.
+type Event struct {
+	ID        uint64
+	LinkID    uint64 `db:"link_id"`
+	Type      Type   `db:"event_type"`
+	Payload   []byte
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// LinkPayload is the state of the link right after the change.
+type LinkPayload struct {
+	ID           uint64     `json:"id"`
+	UserID       uint64     `json:"user_id"`
+	Url          string     `json:"url"`
+	CanonicalUrl string     `json:"canonical_url"`
+	Description  string     `json:"description"`
+	Tags         []string   `json:"tags"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+}
+
+func NewLinkPayload(entity *link.Link) ([]byte, error) {
+	payload := LinkPayload{
+		ID:           entity.ID,
+		UserID:       entity.UserID,
+		Url:          entity.Url,
+		CanonicalUrl: entity.CanonicalUrl,
+		Description:  entity.Description,
+		Tags:         entity.GetTagsAsSlice(),
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+	if entity.DeletedAt.Valid {
+		payload.DeletedAt = &entity.DeletedAt.Time
+	}
+	return json.Marshal(payload)
+}
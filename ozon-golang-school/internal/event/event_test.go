@@ -0,0 +1,58 @@
+package event_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/event"
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+var _ = Describe("Event", func() {
+	It("Link payload", func() {
+		createTime := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+		entity := link.New(2, "https://test.com")
+		entity.ID = 1
+		entity.CreatedAt = createTime
+		entity.UpdatedAt = createTime
+		entity.DeletedAt = sql.NullTime{Time: createTime, Valid: true}
+		entity.SetTagsAsSlice([]string{"go"})
+
+		payload, err := event.NewLinkPayload(entity)
+
+		Expect(err).Should(Succeed())
+		Expect(string(payload)).Should(MatchJSON(`{
+			"id": 1,
+			"user_id": 2,
+			"url": "https://test.com",
+			"canonical_url": "https://test.com",
+			"description": "",
+			"tags": ["go"],
+			"created_at": "2021-10-01T12:00:00Z",
+			"updated_at": "2021-10-01T12:00:00Z",
+			"deleted_at": "2021-10-01T12:00:00Z"
+		}`))
+	})
+
+	It("Memory producer", func() {
+		producer := event.NewMemoryProducer()
+		first := event.Event{ID: 1, LinkID: 1, Type: event.LinkCreated}
+		second := event.Event{ID: 2, LinkID: 1, Type: event.LinkDeleted}
+
+		Expect(producer.Send(context.Background(), first)).Should(Succeed())
+		producer.SetError(errors.New("broker is down"))
+		Expect(producer.Send(context.Background(), second)).ShouldNot(Succeed())
+		producer.SetError(nil)
+		Expect(producer.Send(context.Background(), second)).Should(Succeed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(producer.Send(ctx, first)).Should(MatchError(context.Canceled))
+
+		Expect(producer.Events()).Should(Equal([]event.Event{first, second}))
+	})
+})
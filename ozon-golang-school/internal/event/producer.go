@@ -0,0 +1,104 @@
+package event
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/Shopify/sarama"
+)
+
+// Producer publishes outbox events. Send returns only after the event is stored by the broker.
+type Producer interface {
+	Send(ctx context.Context, event Event) error
+	Close() error
+}
+
+type kafkaProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+// NewKafkaProducer keys messages by link ID, so events of one link land in one
+// partition and keep their order.
+func NewKafkaProducer(brokers []string, topic string) (Producer, error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V1_0_0_0
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Idempotent = true
+	config.Producer.Return.Successes = true
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	// Idempotent delivery allows only one request in flight, which also keeps retries in order.
+	config.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaProducer{producer: producer, topic: topic}, nil
+}
+
+func (kp *kafkaProducer) Send(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	_, _, err := kp.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: kp.topic,
+		Key:   sarama.StringEncoder(strconv.FormatUint(event.LinkID, 10)),
+		Value: sarama.ByteEncoder(event.Payload),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("event_type"), Value: []byte(event.Type)},
+			{Key: []byte("event_id"), Value: []byte(strconv.FormatUint(event.ID, 10))},
+		},
+		Timestamp: event.CreatedAt,
+	})
+	return err
+}
+
+func (kp *kafkaProducer) Close() error {
+	return kp.producer.Close()
+}
+
+// MemoryProducer keeps sent events in memory.
+type MemoryProducer struct {
+	mu     sync.Mutex
+	events []Event
+	err    error
+}
+
+func NewMemoryProducer() *MemoryProducer {
+	return &MemoryProducer{}
+}
+
+func (mp *MemoryProducer) Send(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	if mp.err != nil {
+		return mp.err
+	}
+	mp.events = append(mp.events, event)
+	return nil
+}
+
+func (mp *MemoryProducer) Close() error {
+	return nil
+}
+
+// Events returns a copy of the events sent so far.
+func (mp *MemoryProducer) Events() []Event {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	return append([]Event(nil), mp.events...)
+}
+
+// SetError makes every following Send fail with err until it is reset with nil.
+func (mp *MemoryProducer) SetError(err error) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+	mp.err = err
+}
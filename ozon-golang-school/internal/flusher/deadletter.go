@@ -0,0 +1,75 @@
+package flusher
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+// DeadLetter keeps links the flusher gave up on, so that they can be inspected and imported again.
+type DeadLetter interface {
+	Put(entities []link.Link, cause error) error
+}
+
+type deadLetterRecord struct {
+	UserID       uint64    `json:"user_id"`
+	Url          string    `json:"url"`
+	CanonicalUrl string    `json:"canonical_url"`
+	Description  string    `json:"description"`
+	Tags         []string  `json:"tags,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	Error        string    `json:"error"`
+	FailedAt     time.Time `json:"failed_at"`
+}
+
+// FileDeadLetter appends links as JSON lines to a file, each put is fsynced before it returns.
+type FileDeadLetter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileDeadLetter(path string) (*FileDeadLetter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileDeadLetter{file: file}, nil
+}
+
+func (d *FileDeadLetter) Put(entities []link.Link, cause error) error {
+	failedAt := time.Now()
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entity := range entities {
+		err := encoder.Encode(deadLetterRecord{
+			UserID:       entity.UserID,
+			Url:          entity.Url,
+			CanonicalUrl: entity.CanonicalUrl,
+			Description:  entity.Description,
+			Tags:         entity.Tags,
+			CreatedAt:    entity.CreatedAt,
+			Error:        cause.Error(),
+			FailedAt:     failedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.file.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return d.file.Sync()
+}
+
+func (d *FileDeadLetter) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.file.Close()
+}
@@ -1,36 +1,82 @@
 package flusher
 
 import (
+	"context"
+	"errors"
+
 	"github.com/onsi/ginkgo"
 	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
 	"github.com/ozonva/ova-link-api/internal/repo"
 	"github.com/ozonva/ova-link-api/internal/utils"
 )
 
 type Flusher interface {
-	Flush(entities []link.Link) []link.Link
+	Flush(ctx context.Context, entities []link.Link) []link.Link
 }
 
 type flusher struct {
 	chunkSize  uint
 	entityRepo repo.Repo
+	metrics    *metrics.Metrics
+	retry      RetryPolicy
+	breaker    *Breaker
+	deadLetter DeadLetter
+}
+
+func NewFlusher(chunkSize uint, entityRepo repo.Repo, metrics *metrics.Metrics) Flusher {
+	return NewResilientFlusher(chunkSize, entityRepo, metrics, RetryPolicy{MaxAttempts: 1}, nil, nil)
 }
 
-func NewFlusher(chunkSize uint, entityRepo repo.Repo) Flusher {
+// NewResilientFlusher retries failed chunks with retry and stops writing while breaker is open.
+// Chunks that fail on the last attempt and links the repo rejects go to deadLetter, with a nil
+// deadLetter they are returned as unprocessed like the ones skipped by the breaker.
+// A nil breaker never opens.
+func NewResilientFlusher(
+	chunkSize uint,
+	entityRepo repo.Repo,
+	metrics *metrics.Metrics,
+	retry RetryPolicy,
+	breaker *Breaker,
+	deadLetter DeadLetter,
+) Flusher {
 	return &flusher{
 		chunkSize:  chunkSize,
 		entityRepo: entityRepo,
+		metrics:    metrics,
+		retry:      retry,
+		breaker:    breaker,
+		deadLetter: deadLetter,
 	}
 }
 
-func (f *flusher) Flush(entities []link.Link) []link.Link {
+// Flush stops writing once ctx is done, the chunks left are returned as unprocessed.
+func (f *flusher) Flush(ctx context.Context, entities []link.Link) []link.Link {
 	defer ginkgo.GinkgoRecover()
 	unprocessedEntities := make([]link.Link, 0, len(entities))
 	for _, batch := range utils.SliceChunkLink(entities, f.chunkSize) {
-		err := f.entityRepo.AddEntities(batch)
-		if err != nil {
+		if ctx.Err() != nil || !f.allow() {
 			unprocessedEntities = append(unprocessedEntities, batch...)
+			continue
+		}
+		exhausted, err := f.write(ctx, batch)
+		var batchErr *repo.BatchError
+		if errors.As(err, &batchErr) {
+			f.metrics.ObserveFlushBatch(len(batch), false)
+			unprocessedEntities = append(unprocessedEntities, f.reject(batch, batchErr.Rows)...)
+			continue
 		}
+		f.metrics.ObserveFlushBatch(len(batch), err != nil)
+		if err == nil {
+			continue
+		}
+		if exhausted && f.deadLetter != nil {
+			if dlErr := f.deadLetter.Put(batch, err); dlErr == nil {
+				f.metrics.AddDeadLetterLinks(len(batch))
+				continue
+			}
+		}
+		unprocessedEntities = append(unprocessedEntities, batch...)
 	}
 
 	if len(unprocessedEntities) > 0 {
@@ -39,3 +85,68 @@ func (f *flusher) Flush(entities []link.Link) []link.Link {
 
 	return nil
 }
+
+// write reports whether the batch failed on every attempt of the retry policy,
+// not because ctx is done or the breaker opened in between.
+func (f *flusher) write(ctx context.Context, batch []link.Link) (exhausted bool, err error) {
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if !sleep(ctx, f.retry.Backoff(attempt-1)) || !f.allow() {
+				return false, err
+			}
+			f.metrics.IncFlushRetries()
+		}
+
+		err = f.entityRepo.AddEntities(ctx, batch)
+		if ctx.Err() != nil {
+			return false, err
+		}
+		// The rest of the batch is saved, the rejected links would fail the same way on a retry.
+		var batchErr *repo.BatchError
+		if errors.As(err, &batchErr) {
+			f.report(nil)
+			return false, err
+		}
+		f.report(err)
+		if err == nil || attempt >= f.retry.MaxAttempts {
+			return err != nil, err
+		}
+	}
+}
+
+// reject moves the links the repo rejected to deadLetter one by one, with their own errors.
+// Without deadLetter they are returned as unprocessed.
+func (f *flusher) reject(batch []link.Link, rows []repo.RowError) []link.Link {
+	f.metrics.AddRejectedLinks(len(rows))
+	var unprocessed []link.Link
+	for _, row := range rows {
+		entity := batch[row.Index]
+		if f.deadLetter != nil && f.deadLetter.Put([]link.Link{entity}, row.Err) == nil {
+			f.metrics.AddDeadLetterLinks(1)
+			continue
+		}
+		unprocessed = append(unprocessed, entity)
+	}
+	return unprocessed
+}
+
+func (f *flusher) allow() bool {
+	if f.breaker == nil {
+		return true
+	}
+	allowed := f.breaker.Allow()
+	f.metrics.SetFlushBreakerState(int(f.breaker.State()))
+	return allowed
+}
+
+func (f *flusher) report(err error) {
+	if f.breaker == nil {
+		return
+	}
+	if err != nil {
+		f.breaker.Failure()
+	} else {
+		f.breaker.Success()
+	}
+	f.metrics.SetFlushBreakerState(int(f.breaker.State()))
+}
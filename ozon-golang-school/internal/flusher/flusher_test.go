@@ -1,22 +1,29 @@
 package flusher_test
 
 import (
+	"context"
 	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/ozonva/ova-link-api/internal/flusher"
 	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
 	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 var _ = Describe("Flusher", func() {
 	var ctrl *gomock.Controller
 	var mockRepo *mocks.MockRepo
+	var registry *prometheus.Registry
 
 	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
 		ctrl = gomock.NewController(GinkgoT())
 		mockRepo = mocks.NewMockRepo(ctrl)
 	})
@@ -33,7 +40,7 @@ var _ = Describe("Flusher", func() {
 		var sliceLenAndValuesMatcher3 gomock.Matcher
 
 		BeforeEach(func() {
-			flusherImpl = flusher.NewFlusher(2, mockRepo)
+			flusherImpl = flusher.NewFlusher(2, mockRepo, metrics.New(registry))
 			entities = make([]link.Link, 0, 6)
 			for i := 0; i < 6; i++ {
 				link := link.New(uint64(i), "https://link"+strconv.Itoa(i))
@@ -49,39 +56,73 @@ var _ = Describe("Flusher", func() {
 		Context("Flush chunks successfully.", func() {
 			It("Everything was saved. Should return nil.", func() {
 				gomock.InOrder(
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher1).Return(nil),
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher2).Return(nil),
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher3).Return(nil),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher1).Return(nil),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher2).Return(nil),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher3).Return(nil),
 				)
 
-				Expect(flusherImpl.Flush(entities)).Should(BeNil())
+				Expect(flusherImpl.Flush(context.Background(), entities)).Should(BeNil())
 			})
 		})
 		Context("Flush chunks with errors.", func() {
 			It("One chunk was not saved. Should return all unprocessed entities", func() {
 				gomock.InOrder(
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher1).Return(nil),
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher2).Return(errors.New("something goes wrong")),
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher3).Return(nil),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher1).Return(nil),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher2).Return(errors.New("something goes wrong")),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher3).Return(nil),
 				)
 
 				unprocessed := make([]link.Link, 0, 2)
 				unprocessed = append(unprocessed, entities[2:4]...)
 
-				Expect(flusherImpl.Flush(entities)).Should(BeEquivalentTo(unprocessed))
+				Expect(flusherImpl.Flush(context.Background(), entities)).Should(BeEquivalentTo(unprocessed))
 			})
 			It("Several chunks were not saved. Should return all unprocessed entities", func() {
 				gomock.InOrder(
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher1).Return(errors.New("something goes wrong")),
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher2).Return(nil),
-					mockRepo.EXPECT().AddEntities(sliceLenAndValuesMatcher3).Return(errors.New("something goes wrong")),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher1).Return(errors.New("something goes wrong")),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher2).Return(nil),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher3).Return(errors.New("something goes wrong")),
 				)
 
 				unprocessed := make([]link.Link, 0, 4)
 				unprocessed = append(unprocessed, entities[0:2]...)
 				unprocessed = append(unprocessed, entities[4:6]...)
 
-				Expect(flusherImpl.Flush(entities)).Should(BeEquivalentTo(unprocessed))
+				Expect(flusherImpl.Flush(context.Background(), entities)).Should(BeEquivalentTo(unprocessed))
+
+				expected := `
+# HELP ova_link_api_flusher_failed_links_total Links of batch inserts that failed.
+# TYPE ova_link_api_flusher_failed_links_total counter
+ova_link_api_flusher_failed_links_total 4
+# HELP ova_link_api_flusher_failed_batches_total Batch inserts that failed.
+# TYPE ova_link_api_flusher_failed_batches_total counter
+ova_link_api_flusher_failed_batches_total 2
+`
+				Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected),
+					"ova_link_api_flusher_failed_links_total",
+					"ova_link_api_flusher_failed_batches_total",
+				)).Should(Succeed())
+			})
+		})
+		Context("Flush canceled.", func() {
+			It("Context is already done. Should return everything without writing.", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				Expect(flusherImpl.Flush(ctx, entities)).Should(Equal(entities))
+			})
+			It("Context is canceled during a chunk. Should return it and the rest.", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				gomock.InOrder(
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher1).Return(nil),
+					mockRepo.EXPECT().AddEntities(gomock.Any(), sliceLenAndValuesMatcher2).
+						DoAndReturn(func(ctx context.Context, entities []link.Link) error {
+							cancel()
+							return ctx.Err()
+						}),
+				)
+
+				Expect(flusherImpl.Flush(ctx, entities)).Should(Equal(entities[2:6]))
 			})
 		})
 	})
@@ -0,0 +1,325 @@
+package flusher_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/flusher"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
+	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type deadLetterPut struct {
+	entities []link.Link
+	cause    error
+}
+
+type memoryDeadLetter struct {
+	puts []deadLetterPut
+	err  error
+}
+
+func (d *memoryDeadLetter) Put(entities []link.Link, cause error) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.puts = append(d.puts, deadLetterPut{entities: entities, cause: cause})
+	return nil
+}
+
+var errRepo = errors.New("connection refused")
+
+var _ = Describe("Retry policy", func() {
+	It("Backoff without jitter. Should grow by the multiplier up to the max backoff.", func() {
+		policy := flusher.RetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     time.Second,
+			Multiplier:     3,
+		}
+
+		Expect(policy.Backoff(1)).Should(Equal(100 * time.Millisecond))
+		Expect(policy.Backoff(2)).Should(Equal(300 * time.Millisecond))
+		Expect(policy.Backoff(3)).Should(Equal(900 * time.Millisecond))
+		Expect(policy.Backoff(4)).Should(Equal(time.Second))
+	})
+
+	It("Backoff with jitter. Should shorten the wait by up to the jitter fraction.", func() {
+		policy := flusher.RetryPolicy{
+			InitialBackoff: time.Second,
+			Multiplier:     2,
+			Jitter:         0.5,
+		}
+
+		for i := 0; i < 100; i++ {
+			backoff := policy.Backoff(2)
+			Expect(backoff).Should(BeNumerically(">", time.Second))
+			Expect(backoff).Should(BeNumerically("<=", 2*time.Second))
+		}
+	})
+})
+
+var _ = Describe("Breaker", func() {
+	It("Failures in a row. Should open at the threshold.", func() {
+		breaker := flusher.NewBreaker(3, time.Hour)
+
+		breaker.Failure()
+		breaker.Failure()
+		breaker.Success()
+		breaker.Failure()
+		breaker.Failure()
+		Expect(breaker.Allow()).Should(BeTrue())
+
+		breaker.Failure()
+		Expect(breaker.State()).Should(Equal(flusher.BreakerOpen))
+		Expect(breaker.Allow()).Should(BeFalse())
+	})
+
+	It("Cooldown passed. Should let one trial through and close on its success.", func() {
+		breaker := flusher.NewBreaker(1, 20*time.Millisecond)
+		breaker.Failure()
+		Expect(breaker.Allow()).Should(BeFalse())
+
+		Eventually(breaker.Allow).Should(BeTrue())
+		Expect(breaker.State()).Should(Equal(flusher.BreakerHalfOpen))
+		Expect(breaker.Allow()).Should(BeFalse())
+
+		breaker.Success()
+		Expect(breaker.State()).Should(Equal(flusher.BreakerClosed))
+		Expect(breaker.Allow()).Should(BeTrue())
+	})
+
+	It("Trial failed. Should open again for another cooldown.", func() {
+		breaker := flusher.NewBreaker(3, 20*time.Millisecond)
+		breaker.Failure()
+		breaker.Failure()
+		breaker.Failure()
+
+		Eventually(breaker.Allow).Should(BeTrue())
+		breaker.Failure()
+		Expect(breaker.State()).Should(Equal(flusher.BreakerOpen))
+		Expect(breaker.Allow()).Should(BeFalse())
+	})
+})
+
+var _ = Describe("Resilient flusher", func() {
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockRepo
+	var registry *prometheus.Registry
+	var deadLetter *memoryDeadLetter
+	var entities []link.Link
+	var retry flusher.RetryPolicy
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepo(ctrl)
+		registry = prometheus.NewRegistry()
+		deadLetter = &memoryDeadLetter{}
+		retry = flusher.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     5 * time.Millisecond,
+			Multiplier:     2,
+			Jitter:         0.5,
+		}
+
+		entities = make([]link.Link, 0, 4)
+		for i := 0; i < 4; i++ {
+			entities = append(entities, *link.New(uint64(i), "https://link"+strconv.Itoa(i)))
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	newFlusher := func(breaker *flusher.Breaker) flusher.Flusher {
+		return flusher.NewResilientFlusher(2, mockRepo, metrics.New(registry), retry, breaker, deadLetter)
+	}
+
+	It("Insert failed once. Should retry it and save everything.", func() {
+		gomock.InOrder(
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).Return(errRepo),
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).Return(nil),
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[2:4])).Return(nil),
+		)
+
+		Expect(newFlusher(nil).Flush(context.Background(), entities)).Should(BeNil())
+		Expect(deadLetter.puts).Should(BeEmpty())
+
+		expected := `
+# HELP ova_link_api_flusher_retries_total Batch inserts retried after a failure.
+# TYPE ova_link_api_flusher_retries_total counter
+ova_link_api_flusher_retries_total 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "ova_link_api_flusher_retries_total")).Should(Succeed())
+	})
+
+	It("Insert failed on every attempt. Should move the chunk to the dead letter.", func() {
+		gomock.InOrder(
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).Times(3).Return(errRepo),
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[2:4])).Return(nil),
+		)
+
+		Expect(newFlusher(nil).Flush(context.Background(), entities)).Should(BeNil())
+		Expect(deadLetter.puts).Should(Equal([]deadLetterPut{{entities: entities[0:2], cause: errRepo}}))
+
+		expected := `
+# HELP ova_link_api_flusher_retries_total Batch inserts retried after a failure.
+# TYPE ova_link_api_flusher_retries_total counter
+ova_link_api_flusher_retries_total 2
+# HELP ova_link_api_flusher_dead_letter_links_total Links moved to the dead letter after the last retry failed.
+# TYPE ova_link_api_flusher_dead_letter_links_total counter
+ova_link_api_flusher_dead_letter_links_total 2
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"ova_link_api_flusher_retries_total",
+			"ova_link_api_flusher_dead_letter_links_total",
+		)).Should(Succeed())
+	})
+
+	It("Dead letter failed. Should return the chunk as unprocessed.", func() {
+		deadLetter.err = errors.New("disk full")
+		gomock.InOrder(
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).Times(3).Return(errRepo),
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[2:4])).Return(nil),
+		)
+
+		Expect(newFlusher(nil).Flush(context.Background(), entities)).Should(Equal(entities[0:2]))
+	})
+
+	It("Failures reach the breaker threshold. Should stop writing and return the rest as unprocessed.", func() {
+		mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).Times(2).Return(errRepo)
+
+		breaker := flusher.NewBreaker(2, time.Hour)
+		Expect(newFlusher(breaker).Flush(context.Background(), entities)).Should(Equal(entities))
+		Expect(breaker.State()).Should(Equal(flusher.BreakerOpen))
+		Expect(deadLetter.puts).Should(BeEmpty())
+
+		expected := `
+# HELP ova_link_api_flusher_breaker_state State of the flusher circuit breaker: 0 closed, 1 open, 2 half-open.
+# TYPE ova_link_api_flusher_breaker_state gauge
+ova_link_api_flusher_breaker_state 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "ova_link_api_flusher_breaker_state")).Should(Succeed())
+	})
+
+	It("Repo rejected some links of a chunk. Should move them to the dead letter without retrying.", func() {
+		rowErr := errors.New("invalid input")
+		gomock.InOrder(
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).
+				Return(&repo.BatchError{Rows: []repo.RowError{{Index: 1, Err: rowErr}}}),
+			mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[2:4])).Return(nil),
+		)
+
+		breaker := flusher.NewBreaker(1, time.Hour)
+		Expect(newFlusher(breaker).Flush(context.Background(), entities)).Should(BeNil())
+		Expect(breaker.State()).Should(Equal(flusher.BreakerClosed))
+		Expect(deadLetter.puts).Should(Equal([]deadLetterPut{{entities: entities[1:2], cause: rowErr}}))
+
+		expected := `
+# HELP ova_link_api_flusher_rejected_links_total Links the repo rejected and isolated from the rest of their batch.
+# TYPE ova_link_api_flusher_rejected_links_total counter
+ova_link_api_flusher_rejected_links_total 1
+# HELP ova_link_api_flusher_retries_total Batch inserts retried after a failure.
+# TYPE ova_link_api_flusher_retries_total counter
+ova_link_api_flusher_retries_total 0
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"ova_link_api_flusher_rejected_links_total",
+			"ova_link_api_flusher_retries_total",
+		)).Should(Succeed())
+	})
+
+	It("Repo rejected some links without a dead letter. Should return only them as unprocessed.", func() {
+		mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).
+			Return(&repo.BatchError{Rows: []repo.RowError{{Index: 0, Err: errRepo}}})
+		mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[2:4])).Return(nil)
+
+		flusherImpl := flusher.NewResilientFlusher(2, mockRepo, metrics.New(registry), retry, nil, nil)
+		Expect(flusherImpl.Flush(context.Background(), entities)).Should(Equal(entities[0:1]))
+	})
+
+	It("Breaker is open. Should not touch the repo.", func() {
+		breaker := flusher.NewBreaker(1, time.Hour)
+		breaker.Failure()
+
+		Expect(newFlusher(breaker).Flush(context.Background(), entities)).Should(Equal(entities))
+	})
+
+	It("Cooldown passed and the trial insert succeeded. Should close the breaker and save everything.", func() {
+		mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+		breaker := flusher.NewBreaker(1, 20*time.Millisecond)
+		breaker.Failure()
+		time.Sleep(30 * time.Millisecond)
+
+		Expect(newFlusher(breaker).Flush(context.Background(), entities)).Should(BeNil())
+		Expect(breaker.State()).Should(Equal(flusher.BreakerClosed))
+	})
+
+	It("Context is canceled during an insert. Should return the chunk without retrying or dead-lettering it.", func() {
+		retry.InitialBackoff = time.Hour
+		ctx, cancel := context.WithCancel(context.Background())
+		mockRepo.EXPECT().AddEntities(gomock.Any(), gomock.Eq(entities[0:2])).
+			DoAndReturn(func(ctx context.Context, entities []link.Link) error {
+				cancel()
+				return errRepo
+			})
+
+		breaker := flusher.NewBreaker(1, time.Hour)
+		Expect(newFlusher(breaker).Flush(ctx, entities)).Should(Equal(entities))
+		Expect(breaker.State()).Should(Equal(flusher.BreakerClosed))
+		Expect(deadLetter.puts).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("File dead letter", func() {
+	It("Put. Should append links as JSON lines with the error.", func() {
+		dir, err := ioutil.TempDir("", "deadletter")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "dead-letter.jsonl")
+
+		deadLetter, err := flusher.NewFileDeadLetter(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deadLetter.Put([]link.Link{*link.New(1, "https://link1"), *link.New(2, "https://link2")}, errRepo)).Should(Succeed())
+		Expect(deadLetter.Close()).Should(Succeed())
+
+		deadLetter, err = flusher.NewFileDeadLetter(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(deadLetter.Put([]link.Link{*link.New(3, "https://link3")}, errRepo)).Should(Succeed())
+		Expect(deadLetter.Close()).Should(Succeed())
+
+		file, err := os.Open(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer file.Close()
+
+		var lines []map[string]interface{}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var line map[string]interface{}
+			Expect(json.Unmarshal(scanner.Bytes(), &line)).Should(Succeed())
+			lines = append(lines, line)
+		}
+		Expect(lines).Should(HaveLen(3))
+		Expect(lines[2]).Should(HaveKeyWithValue("user_id", BeEquivalentTo(3)))
+		Expect(lines[2]).Should(HaveKeyWithValue("url", "https://link3"))
+		Expect(lines[2]).Should(HaveKeyWithValue("error", errRepo.Error()))
+	})
+})
@@ -0,0 +1,50 @@
+package flusher
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryPolicy makes MaxAttempts batch inserts at most. The wait before a retry starts at
+// InitialBackoff and grows Multiplier times with every retry up to MaxBackoff, Jitter randomly
+// shortens it by up to that fraction so that flushers don't retry in lockstep.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+}
+
+var jitterRand = struct {
+	sync.Mutex
+	*rand.Rand
+}{Rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// Backoff is the wait before the retry-th retry, retries are counted from 1.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitterRand.Lock()
+		backoff -= backoff * p.Jitter * jitterRand.Float64()
+		jitterRand.Unlock()
+	}
+	return time.Duration(backoff)
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
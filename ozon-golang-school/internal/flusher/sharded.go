@@ -0,0 +1,54 @@
+package flusher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+type shardedFlusher struct {
+	workers uint
+	flusher Flusher
+}
+
+// NewShardedFlusher splits entities into workers shards by UserID and flushes the shards with
+// flusher in parallel, so flusher has to be safe for concurrent use. Links of one user always
+// fall into the same shard and are written in order. Unprocessed links of all shards are returned.
+func NewShardedFlusher(workers uint, flusher Flusher) Flusher {
+	if workers == 0 {
+		workers = 1
+	}
+	return &shardedFlusher{
+		workers: workers,
+		flusher: flusher,
+	}
+}
+
+func (f *shardedFlusher) Flush(ctx context.Context, entities []link.Link) []link.Link {
+	shards := make([][]link.Link, f.workers)
+	for _, entity := range entities {
+		shard := entity.UserID % uint64(f.workers)
+		shards[shard] = append(shards[shard], entity)
+	}
+
+	results := make([][]link.Link, f.workers)
+	var wg sync.WaitGroup
+	for i, shard := range shards {
+		if len(shard) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, shard []link.Link) {
+			defer wg.Done()
+			results[i] = f.flusher.Flush(ctx, shard)
+		}(i, shard)
+	}
+	wg.Wait()
+
+	var unprocessedEntities []link.Link
+	for _, result := range results {
+		unprocessedEntities = append(unprocessedEntities, result...)
+	}
+	return unprocessedEntities
+}
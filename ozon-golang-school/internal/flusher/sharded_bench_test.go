@@ -0,0 +1,66 @@
+package flusher_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/flusher"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	benchLinks     = 1000
+	benchUsers     = 100
+	benchChunkSize = 10
+	benchLatency   = time.Millisecond
+)
+
+// latencyRepo stands for a database that takes latency for every batch insert.
+type latencyRepo struct {
+	repo.Repo
+	latency time.Duration
+}
+
+func (r latencyRepo) AddEntities(ctx context.Context, entities []link.Link) error {
+	time.Sleep(r.latency)
+	return nil
+}
+
+// BenchmarkFlush compares the sequential flusher with the sharded one on an import of many users:
+// go test -run=^$ -bench=Flush ./internal/flusher
+func BenchmarkFlush(b *testing.B) {
+	entities := make([]link.Link, 0, benchLinks)
+	for i := 0; i < benchLinks; i++ {
+		entities = append(entities, *link.New(uint64(i%benchUsers), fmt.Sprintf("https://bench.test/%d", i)))
+	}
+
+	newFlusher := func() flusher.Flusher {
+		return flusher.NewFlusher(benchChunkSize, latencyRepo{latency: benchLatency}, metrics.New(prometheus.NewRegistry()))
+	}
+	flushers := []struct {
+		name    string
+		flusher flusher.Flusher
+	}{
+		{name: "sequential", flusher: newFlusher()},
+		{name: "sharded/2", flusher: flusher.NewShardedFlusher(2, newFlusher())},
+		{name: "sharded/4", flusher: flusher.NewShardedFlusher(4, newFlusher())},
+		{name: "sharded/8", flusher: flusher.NewShardedFlusher(8, newFlusher())},
+	}
+	for _, bench := range flushers {
+		bench := bench
+		b.Run(bench.name, func(b *testing.B) {
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				if unprocessed := bench.flusher.Flush(context.Background(), entities); len(unprocessed) > 0 {
+					b.Fatalf("%d links are unprocessed", len(unprocessed))
+				}
+			}
+			b.ReportMetric(float64(b.N*benchLinks)/time.Since(start).Seconds(), "links/s")
+		})
+	}
+}
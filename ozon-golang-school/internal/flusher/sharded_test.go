@@ -0,0 +1,118 @@
+package flusher_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/flusher"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
+	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// recordingFlusher keeps the shards it is given and waits for release before returning.
+type recordingFlusher struct {
+	mu      sync.Mutex
+	shards  [][]link.Link
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *recordingFlusher) Flush(ctx context.Context, entities []link.Link) []link.Link {
+	f.mu.Lock()
+	f.shards = append(f.shards, entities)
+	f.mu.Unlock()
+
+	if f.started != nil {
+		f.started <- struct{}{}
+	}
+	if f.release != nil {
+		<-f.release
+	}
+	return nil
+}
+
+var _ = Describe("Sharded flusher", func() {
+	var entities []link.Link
+
+	BeforeEach(func() {
+		entities = make([]link.Link, 0, 12)
+		for i := 0; i < 12; i++ {
+			entities = append(entities, *link.New(uint64(i%4), "https://link"+strconv.Itoa(i)))
+		}
+	})
+
+	It("Links of many users. Should keep links of a user in one shard in order.", func() {
+		recorder := &recordingFlusher{}
+		shardedFlusher := flusher.NewShardedFlusher(2, recorder)
+
+		Expect(shardedFlusher.Flush(context.Background(), entities)).Should(BeEmpty())
+
+		Expect(recorder.shards).Should(HaveLen(2))
+		for _, shard := range recorder.shards {
+			var expected []link.Link
+			for _, entity := range entities {
+				if entity.UserID%2 == shard[0].UserID%2 {
+					expected = append(expected, entity)
+				}
+			}
+			Expect(shard).Should(Equal(expected))
+		}
+	})
+
+	It("Links of many users. Should flush the shards in parallel.", func() {
+		recorder := &recordingFlusher{started: make(chan struct{}), release: make(chan struct{})}
+		shardedFlusher := flusher.NewShardedFlusher(4, recorder)
+
+		done := make(chan []link.Link)
+		go func() {
+			done <- shardedFlusher.Flush(context.Background(), entities)
+		}()
+
+		for i := 0; i < 4; i++ {
+			Eventually(recorder.started).Should(Receive())
+		}
+		Consistently(done).ShouldNot(Receive())
+		close(recorder.release)
+		Eventually(done).Should(Receive(BeEmpty()))
+	})
+
+	It("Zero workers. Should flush everything in one shard.", func() {
+		recorder := &recordingFlusher{}
+		shardedFlusher := flusher.NewShardedFlusher(0, recorder)
+
+		Expect(shardedFlusher.Flush(context.Background(), entities)).Should(BeEmpty())
+
+		Expect(recorder.shards).Should(Equal([][]link.Link{entities}))
+	})
+
+	It("A shard failed. Should return unprocessed links of every failed shard.", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		mockRepo := mocks.NewMockRepo(ctrl)
+		byUser := func(userID uint64) []link.Link {
+			var userEntities []link.Link
+			for _, entity := range entities {
+				if entity.UserID == userID {
+					userEntities = append(userEntities, entity)
+				}
+			}
+			return userEntities
+		}
+
+		mockRepo.EXPECT().AddEntities(gomock.Any(), byUser(0)).Return(nil)
+		mockRepo.EXPECT().AddEntities(gomock.Any(), byUser(1)).Return(errRepo)
+		mockRepo.EXPECT().AddEntities(gomock.Any(), byUser(2)).Return(nil)
+		mockRepo.EXPECT().AddEntities(gomock.Any(), byUser(3)).Return(errRepo)
+		shardedFlusher := flusher.NewShardedFlusher(4, flusher.NewFlusher(3, mockRepo, metrics.New(prometheus.NewRegistry())))
+
+		unprocessed := shardedFlusher.Flush(context.Background(), entities)
+
+		Expect(unprocessed).Should(Equal(append(byUser(1), byUser(3)...)))
+	})
+})
@@ -0,0 +1,83 @@
+package link
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+var trackingParams = map[string]bool{
+	"fbclid":    true,
+	"gclid":     true,
+	"dclid":     true,
+	"msclkid":   true,
+	"yclid":     true,
+	"igshid":    true,
+	"mc_cid":    true,
+	"mc_eid":    true,
+	"_openstat": true,
+}
+
+const trackingParamPrefix = "utm_"
+
+// Canonicalize returns the form of rawUrl used to detect duplicates.
+// http and https are treated as the same page, so the canonical scheme is always https.
+func Canonicalize(rawUrl string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil {
+		return "", err
+	}
+	if !parsed.IsAbs() || parsed.Host == "" {
+		return "", fmt.Errorf("url %q is not absolute", rawUrl)
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	host, err := canonicalHost(parsed.Hostname())
+	if err != nil {
+		return "", err
+	}
+	if port := parsed.Port(); port != "" && port != defaultPorts[scheme] {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if scheme == "http" {
+		scheme = "https"
+	}
+
+	query := parsed.Query()
+	for param := range query {
+		if trackingParams[strings.ToLower(param)] || strings.HasPrefix(strings.ToLower(param), trackingParamPrefix) {
+			query.Del(param)
+		}
+	}
+
+	canonical := url.URL{
+		Scheme:   scheme,
+		User:     parsed.User,
+		Host:     host,
+		Path:     strings.TrimRight(parsed.Path, "/"),
+		RawPath:  strings.TrimRight(parsed.RawPath, "/"),
+		RawQuery: query.Encode(),
+	}
+	return canonical.String(), nil
+}
+
+func canonicalHost(hostname string) (string, error) {
+	if ip := net.ParseIP(hostname); ip != nil {
+		return ip.String(), nil
+	}
+	host, err := idna.Lookup.ToASCII(strings.TrimSuffix(hostname, "."))
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", hostname, err)
+	}
+	return host, nil
+}
@@ -0,0 +1,50 @@
+package link
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Canonical URL.", func() {
+	DescribeTable("Canonicalize",
+		func(url string, expected string) {
+			canonical, err := Canonicalize(url)
+
+			Expect(err).Should(Succeed())
+			Expect(canonical).Should(Equal(expected))
+		},
+		Entry("already canonical", "https://x.com/a", "https://x.com/a"),
+		Entry("http scheme", "http://x.com/a", "https://x.com/a"),
+		Entry("upper case scheme and host", "HTTPS://X.COM/A", "https://x.com/A"),
+		Entry("trailing slash", "https://x.com/a/", "https://x.com/a"),
+		Entry("root path", "https://x.com/", "https://x.com"),
+		Entry("default http port", "http://x.com:80/a", "https://x.com/a"),
+		Entry("default https port", "https://x.com:443/a", "https://x.com/a"),
+		Entry("custom port", "https://x.com:8080/a", "https://x.com:8080/a"),
+		Entry("tracking params", "https://x.com/a?utm_source=mail&UTM_Medium=web&fbclid=1", "https://x.com/a"),
+		Entry("sorted query", "https://x.com/a?b=2&utm_campaign=x&a=1", "https://x.com/a?a=1&b=2"),
+		Entry("fragment", "https://x.com/a#section", "https://x.com/a"),
+		Entry("idn host", "https://Пример.рф/a", "https://xn--e1afmkfd.xn--p1ai/a"),
+		Entry("ipv6 host", "http://[::1]:80/a", "https://[::1]/a"),
+	)
+
+	It("Relative URL can not be canonicalized", func() {
+		_, err := Canonicalize("/a")
+
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("New stores canonical URL alongside the original", func() {
+		linkEntity := New(1, "http://x.com/a/?utm_source=mail")
+
+		Expect(linkEntity.Url).Should(Equal("http://x.com/a/?utm_source=mail"))
+		Expect(linkEntity.CanonicalUrl).Should(Equal("https://x.com/a"))
+	})
+
+	It("Invalid URL is stored as is", func() {
+		linkEntity := New(1, "not a url")
+
+		Expect(linkEntity.CanonicalUrl).Should(Equal("not a url"))
+	})
+})
@@ -0,0 +1,15 @@
+package link
+
+import "time"
+
+type Check struct {
+	LinkID     uint64    `db:"link_id"`
+	StatusCode int       `db:"status_code"`
+	FinalUrl   string    `db:"final_url"`
+	Error      string    `db:"error"`
+	CheckedAt  time.Time `db:"checked_at"`
+}
+
+func (c *Check) Broken() bool {
+	return c.Error != "" || c.StatusCode >= 400
+}
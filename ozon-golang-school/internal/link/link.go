@@ -1,23 +1,52 @@
 package link
 
 import (
+	"database/sql"
 	"fmt"
 	"sort"
-	"strings"
 	"time"
 )
 
 type Link struct {
-	ID          uint64
-	UserID      uint64 `db:"user_id"`
-	Url         string
-	Description string
-	Tags        string
-	CreatedAt   time.Time `db:"created_at"`
+	ID           uint64
+	UserID       uint64 `db:"user_id"`
+	Url          string
+	CanonicalUrl string `db:"canonical_url"`
+	Description  string
+	Tags         []string     `db:"-"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+	DeletedAt    sql.NullTime `db:"deleted_at"`
+	LastCheck    *Check       `db:"-"`
+	Metadata
+}
+
+type Metadata struct {
+	Title            string       `db:"title"`
+	PageDescription  string       `db:"page_description"`
+	ImageUrl         string       `db:"image_url"`
+	FaviconUrl       string       `db:"favicon_url"`
+	PageCanonicalUrl string       `db:"page_canonical_url"`
+	EnrichedAt       sql.NullTime `db:"enriched_at"`
 }
 
 func New(userID uint64, url string) *Link {
-	return &Link{0, userID, url, "", "", time.Now()}
+	now := time.Now()
+	result := &Link{
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	result.SetUrl(url)
+	return result
+}
+
+func (l *Link) SetUrl(url string) {
+	l.Url = url
+	l.CanonicalUrl = url
+	if canonical, err := Canonicalize(url); err == nil {
+		l.CanonicalUrl = canonical
+	}
 }
 
 func (l *Link) String() string {
@@ -52,8 +81,10 @@ func (l *Link) Equals(inputLink *Link) bool {
 	}
 	sort.Strings(tags)
 	sort.Strings(inputTags)
-	if strings.Join(tags, "#") != strings.Join(inputTags, "#") {
-		return false
+	for i := range tags {
+		if tags[i] != inputTags[i] {
+			return false
+		}
 	}
 
 	if !l.CreatedAt.Equal(inputLink.CreatedAt) {
@@ -63,27 +94,39 @@ func (l *Link) Equals(inputLink *Link) bool {
 	return true
 }
 
-func (l *Link) AddTag(tag string) {
-	if strings.Index(l.Tags, tag) == -1 {
-		if len(l.Tags) > 0 {
-			l.Tags += "#" + tag
-		} else {
-			l.Tags += tag
+func (l *Link) HasTag(tag string) bool {
+	for _, existing := range l.Tags {
+		if existing == tag {
+			return true
 		}
 	}
+	return false
+}
+
+func (l *Link) AddTag(tag string) {
+	if !l.HasTag(tag) {
+		l.Tags = append(l.Tags, tag)
+	}
 }
 
 func (l *Link) RemoveTag(tag string) {
-	l.Tags = strings.Replace(l.Tags, tag, "", 1)
-	l.Tags = strings.ReplaceAll(l.Tags, "##", "#")
-	l.Tags = strings.TrimPrefix(l.Tags, "#")
-	l.Tags = strings.TrimSuffix(l.Tags, "#")
+	for i, existing := range l.Tags {
+		if existing == tag {
+			l.Tags = append(l.Tags[:i:i], l.Tags[i+1:]...)
+			return
+		}
+	}
 }
 
 func (l *Link) GetTagsAsSlice() []string {
-	return strings.Split(l.Tags, "#")
+	tags := make([]string, len(l.Tags))
+	copy(tags, l.Tags)
+	return tags
 }
 
 func (l *Link) SetTagsAsSlice(tags []string) {
-	l.Tags = strings.Join(tags, "#")
+	l.Tags = nil
+	for _, tag := range tags {
+		l.AddTag(tag)
+	}
 }
@@ -11,7 +11,7 @@ var _ = Describe("Link.", func() {
 	id := uint64(1)
 	userId := uint64(2)
 	url := "https://test.com"
-	expected := &Link{id, userId, url, "", "", time.Now()}
+	expected := &Link{ID: id, UserID: userId, Url: url, CreatedAt: time.Now(), UpdatedAt: time.Now()}
 
 	Context("Creation.", func() {
 		linkEntity := New(userId, url)
@@ -50,7 +50,7 @@ var _ = Describe("Link.", func() {
 			Expect(linkEntity.Description).ShouldNot(BeIdenticalTo(expected.Description))
 		})
 		It("Tags should be updated", func() {
-			Expect(linkEntity.Tags).Should(BeEquivalentTo("tag1#tag2"))
+			Expect(linkEntity.Tags).Should(Equal([]string{"tag1", "tag2"}))
 			Expect(linkEntity.Tags).ShouldNot(BeEquivalentTo(expected.Tags))
 		})
 	})
@@ -65,7 +65,7 @@ var _ = Describe("Link.", func() {
 			linkEntity.AddTag("tag1")
 			linkEntity.AddTag("tag2")
 
-			Expect(linkEntity.Tags).Should(BeIdenticalTo("tag1#tag2#tag3#tag4"))
+			Expect(linkEntity.Tags).Should(Equal([]string{"tag1", "tag2", "tag3", "tag4"}))
 		})
 		It("Tags should be removed.", func() {
 			linkEntity.RemoveTag("tag3")
@@ -73,7 +73,21 @@ var _ = Describe("Link.", func() {
 			linkEntity.RemoveTag("tag3")
 			linkEntity.RemoveTag("tag1")
 
-			Expect(linkEntity.Tags).Should(BeIdenticalTo("tag2#tag4"))
+			Expect(linkEntity.Tags).Should(Equal([]string{"tag2", "tag4"}))
+		})
+		It("Tags should be matched exactly.", func() {
+			linkEntity.SetTagsAsSlice([]string{"golang"})
+			linkEntity.AddTag("go")
+			Expect(linkEntity.Tags).Should(Equal([]string{"golang", "go"}))
+
+			linkEntity.RemoveTag("go")
+			linkEntity.RemoveTag("lang")
+			Expect(linkEntity.Tags).Should(Equal([]string{"golang"}))
+		})
+		It("Tags slice should be a copy.", func() {
+			tags := linkEntity.GetTagsAsSlice()
+			tags[0] = "changed"
+			Expect(linkEntity.Tags).Should(Equal([]string{"golang"}))
 		})
 	})
 	Context("Compare with another link entity.", func() {
@@ -126,8 +140,8 @@ var _ = Describe("Link.", func() {
 UserID: 2,
 URL: "https://test.com",
 Description: "Ozon Go School. Project.",
-Tags: "tag1#tag2",
-DateCreated: [0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}\+[0-9]{2}:[0-9]{2}`
+Tags: \["tag1" "tag2"\],
+DateCreated: [0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(Z|[+-][0-9]{2}:[0-9]{2})`
 		Expect(linkEntity.String()).Should(MatchRegexp(regexpString))
 	})
 })
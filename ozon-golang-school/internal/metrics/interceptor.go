@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		m.observeRPC(info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		m.observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (m *Metrics) observeRPC(method string, start time.Time, err error) {
+	m.rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
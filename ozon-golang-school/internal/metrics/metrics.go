@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ova_link_api"
+
+type FlushTrigger string
+
+const (
+	FlushByCapacity FlushTrigger = "capacity"
+	FlushByTicker   FlushTrigger = "ticker"
+	FlushByClose    FlushTrigger = "close"
+	FlushByReplay   FlushTrigger = "replay"
+)
+
+type Metrics struct {
+	rpcRequests       *prometheus.CounterVec
+	rpcDuration       *prometheus.HistogramVec
+	saverBuffer       prometheus.Gauge
+	saverFlushes      *prometheus.CounterVec
+	saverOverflow     *prometheus.CounterVec
+	flushBatchSize    prometheus.Histogram
+	flushFailedLinks  prometheus.Counter
+	flushFailedChunks prometheus.Counter
+	flushRetries      prometheus.Counter
+	flushDeadLetter   prometheus.Counter
+	flushRejected     prometheus.Counter
+	flushBreaker      prometheus.Gauge
+}
+
+// New registers the collectors in registerer. Every test should pass its own
+// prometheus.NewRegistry(), registering twice in one registry panics.
+func New(registerer prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "requests_total",
+			Help:      "Handled RPCs by method and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of handled RPCs by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		saverBuffer: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "saver",
+			Name:      "buffer_length",
+			Help:      "Links waiting in the saver buffer.",
+		}),
+		saverFlushes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "saver",
+			Name:      "flushes_total",
+			Help:      "Saver flushes by trigger: capacity, ticker, close or replay.",
+		}, []string{"trigger"}),
+		saverOverflow: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "saver",
+			Name:      "overflow_links_total",
+			Help:      "Links dropped or rejected by the saver because its queue was full, by overflow policy.",
+		}, []string{"policy"}),
+		flushBatchSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "flusher",
+			Name:      "batch_size",
+			Help:      "Links written to the repo by one batch insert.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 11),
+		}),
+		flushFailedLinks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "flusher",
+			Name:      "failed_links_total",
+			Help:      "Links of batch inserts that failed.",
+		}),
+		flushFailedChunks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "flusher",
+			Name:      "failed_batches_total",
+			Help:      "Batch inserts that failed.",
+		}),
+		flushRetries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "flusher",
+			Name:      "retries_total",
+			Help:      "Batch inserts retried after a failure.",
+		}),
+		flushDeadLetter: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "flusher",
+			Name:      "dead_letter_links_total",
+			Help:      "Links moved to the dead letter after the last retry failed.",
+		}),
+		flushRejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "flusher",
+			Name:      "rejected_links_total",
+			Help:      "Links the repo rejected and isolated from the rest of their batch.",
+		}),
+		flushBreaker: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "flusher",
+			Name:      "breaker_state",
+			Help:      "State of the flusher circuit breaker: 0 closed, 1 open, 2 half-open.",
+		}),
+	}
+
+	registerer.MustRegister(
+		m.rpcRequests,
+		m.rpcDuration,
+		m.saverBuffer,
+		m.saverFlushes,
+		m.saverOverflow,
+		m.flushBatchSize,
+		m.flushFailedLinks,
+		m.flushFailedChunks,
+		m.flushRetries,
+		m.flushDeadLetter,
+		m.flushRejected,
+		m.flushBreaker,
+	)
+	return m
+}
+
+// RegisterDBStats exports the sql.DB.Stats() of the connection pool.
+func RegisterDBStats(registerer prometheus.Registerer, db *sql.DB, dbName string) error {
+	return registerer.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+func Handler(gatherer prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) SetSaverBufferLength(length int) {
+	m.saverBuffer.Set(float64(length))
+}
+
+func (m *Metrics) IncSaverFlushes(trigger FlushTrigger) {
+	m.saverFlushes.WithLabelValues(string(trigger)).Inc()
+}
+
+func (m *Metrics) AddSaverOverflow(policy string, count int) {
+	m.saverOverflow.WithLabelValues(policy).Add(float64(count))
+}
+
+func (m *Metrics) ObserveFlushBatch(size int, failed bool) {
+	m.flushBatchSize.Observe(float64(size))
+	if failed {
+		m.flushFailedChunks.Inc()
+		m.flushFailedLinks.Add(float64(size))
+	}
+}
+
+func (m *Metrics) IncFlushRetries() {
+	m.flushRetries.Inc()
+}
+
+func (m *Metrics) AddDeadLetterLinks(count int) {
+	m.flushDeadLetter.Add(float64(count))
+}
+
+func (m *Metrics) AddRejectedLinks(count int) {
+	m.flushRejected.Add(float64(count))
+}
+
+func (m *Metrics) SetFlushBreakerState(state int) {
+	m.flushBreaker.Set(float64(state))
+}
@@ -0,0 +1,139 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func histogramCount(registry *prometheus.Registry, name string) uint64 {
+	families, err := registry.Gather()
+	Expect(err).ShouldNot(HaveOccurred())
+	var count uint64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			count += metric.GetHistogram().GetSampleCount()
+		}
+	}
+	return count
+}
+
+type serverStream struct {
+	grpc.ServerStream
+}
+
+var _ = Describe("Metrics", func() {
+	var registry *prometheus.Registry
+	var linkMetrics *metrics.Metrics
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
+		linkMetrics = metrics.New(registry)
+	})
+
+	It("Unary interceptor. Should count requests by method and code.", func() {
+		interceptor := linkMetrics.UnaryServerInterceptor()
+		info := &grpc.UnaryServerInfo{FullMethod: "/ova.link.api.LinkAPI/DescribeLink"}
+
+		res, err := interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "res", nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res).Should(Equal("res"))
+
+		for i := 0; i < 2; i++ {
+			_, err = interceptor(context.Background(), "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, status.Error(codes.NotFound, "not found")
+			})
+			Expect(status.Code(err)).Should(Equal(codes.NotFound))
+		}
+
+		expected := `
+# HELP ova_link_api_grpc_requests_total Handled RPCs by method and status code.
+# TYPE ova_link_api_grpc_requests_total counter
+ova_link_api_grpc_requests_total{code="NotFound",method="/ova.link.api.LinkAPI/DescribeLink"} 2
+ova_link_api_grpc_requests_total{code="OK",method="/ova.link.api.LinkAPI/DescribeLink"} 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "ova_link_api_grpc_requests_total")).Should(Succeed())
+		Expect(histogramCount(registry, "ova_link_api_grpc_request_duration_seconds")).Should(BeEquivalentTo(3))
+	})
+
+	It("Stream interceptor. Should count requests by method and code.", func() {
+		interceptor := linkMetrics.StreamServerInterceptor()
+		info := &grpc.StreamServerInfo{FullMethod: "/ova.link.api.LinkAPI/ExportLinks", IsServerStream: true}
+
+		err := interceptor(nil, &serverStream{}, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return context.Canceled
+		})
+		Expect(err).Should(Equal(context.Canceled))
+
+		expected := `
+# HELP ova_link_api_grpc_requests_total Handled RPCs by method and status code.
+# TYPE ova_link_api_grpc_requests_total counter
+ova_link_api_grpc_requests_total{code="Unknown",method="/ova.link.api.LinkAPI/ExportLinks"} 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "ova_link_api_grpc_requests_total")).Should(Succeed())
+		Expect(histogramCount(registry, "ova_link_api_grpc_request_duration_seconds")).Should(BeEquivalentTo(1))
+	})
+
+	It("Saver and flusher. Should track buffer, triggers and failed links.", func() {
+		linkMetrics.SetSaverBufferLength(4)
+		linkMetrics.IncSaverFlushes(metrics.FlushByCapacity)
+		linkMetrics.IncSaverFlushes(metrics.FlushByTicker)
+		linkMetrics.IncSaverFlushes(metrics.FlushByTicker)
+		linkMetrics.ObserveFlushBatch(3, false)
+		linkMetrics.ObserveFlushBatch(2, true)
+
+		expected := `
+# HELP ova_link_api_saver_buffer_length Links waiting in the saver buffer.
+# TYPE ova_link_api_saver_buffer_length gauge
+ova_link_api_saver_buffer_length 4
+# HELP ova_link_api_saver_flushes_total Saver flushes by trigger: capacity, ticker, close or replay.
+# TYPE ova_link_api_saver_flushes_total counter
+ova_link_api_saver_flushes_total{trigger="capacity"} 1
+ova_link_api_saver_flushes_total{trigger="ticker"} 2
+# HELP ova_link_api_flusher_failed_links_total Links of batch inserts that failed.
+# TYPE ova_link_api_flusher_failed_links_total counter
+ova_link_api_flusher_failed_links_total 2
+# HELP ova_link_api_flusher_failed_batches_total Batch inserts that failed.
+# TYPE ova_link_api_flusher_failed_batches_total counter
+ova_link_api_flusher_failed_batches_total 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"ova_link_api_saver_buffer_length",
+			"ova_link_api_saver_flushes_total",
+			"ova_link_api_flusher_failed_links_total",
+			"ova_link_api_flusher_failed_batches_total",
+		)).Should(Succeed())
+		Expect(histogramCount(registry, "ova_link_api_flusher_batch_size")).Should(BeEquivalentTo(2))
+	})
+
+	It("Handler. Should expose registered metrics and db pool stats.", func() {
+		db, _, err := sqlxmock.Newx()
+		Expect(err).ShouldNot(HaveOccurred())
+		defer db.Close()
+		Expect(metrics.RegisterDBStats(registry, db.DB, "user_links")).Should(Succeed())
+		linkMetrics.SetSaverBufferLength(1)
+
+		recorder := httptest.NewRecorder()
+		metrics.Handler(registry).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		Expect(recorder.Code).Should(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).Should(ContainSubstring("ova_link_api_saver_buffer_length 1"))
+		Expect(recorder.Body.String()).Should(ContainSubstring(`go_sql_max_open_connections{db_name="user_links"}`))
+	})
+})
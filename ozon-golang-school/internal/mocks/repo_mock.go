@@ -5,10 +5,14 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	event "github.com/ozonva/ova-link-api/internal/event"
 	link "github.com/ozonva/ova-link-api/internal/link"
+	repo "github.com/ozonva/ova-link-api/internal/repo"
 )
 
 // MockRepo is a mock of Repo interface.
@@ -34,60 +38,327 @@ func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
 	return m.recorder
 }
 
+// AddChecks mocks base method.
+func (m *MockRepo) AddChecks(arg0 context.Context, arg1 []link.Check) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddChecks indicates an expected call of AddChecks.
+func (mr *MockRepoMockRecorder) AddChecks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecks", reflect.TypeOf((*MockRepo)(nil).AddChecks), arg0, arg1)
+}
+
 // AddEntities mocks base method.
-func (m *MockRepo) AddEntities(arg0 []link.Link) error {
+func (m *MockRepo) AddEntities(arg0 context.Context, arg1 []link.Link) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddEntities", arg0)
+	ret := m.ctrl.Call(m, "AddEntities", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddEntities indicates an expected call of AddEntities.
-func (mr *MockRepoMockRecorder) AddEntities(arg0 interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) AddEntities(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntities", reflect.TypeOf((*MockRepo)(nil).AddEntities), arg0, arg1)
+}
+
+// AddEntity mocks base method.
+func (m *MockRepo) AddEntity(arg0 context.Context, arg1 link.Link) (*link.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEntity", arg0, arg1)
+	ret0, _ := ret[0].(*link.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddEntity indicates an expected call of AddEntity.
+func (mr *MockRepoMockRecorder) AddEntity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntity", reflect.TypeOf((*MockRepo)(nil).AddEntity), arg0, arg1)
+}
+
+// CountEntities mocks base method.
+func (m *MockRepo) CountEntities(arg0 context.Context, arg1 repo.Filter) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEntities", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEntities indicates an expected call of CountEntities.
+func (mr *MockRepoMockRecorder) CountEntities(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEntities", reflect.TypeOf((*MockRepo)(nil).AddEntities), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEntities", reflect.TypeOf((*MockRepo)(nil).CountEntities), arg0, arg1)
 }
 
 // DeleteEntity mocks base method.
-func (m *MockRepo) DeleteEntity(arg0 uint64) error {
+func (m *MockRepo) DeleteEntity(arg0 context.Context, arg1 uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEntity", arg0)
+	ret := m.ctrl.Call(m, "DeleteEntity", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteEntity indicates an expected call of DeleteEntity.
-func (mr *MockRepoMockRecorder) DeleteEntity(arg0 interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) DeleteEntity(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntity", reflect.TypeOf((*MockRepo)(nil).DeleteEntity), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntity", reflect.TypeOf((*MockRepo)(nil).DeleteEntity), arg0, arg1)
 }
 
 // DescribeEntity mocks base method.
-func (m *MockRepo) DescribeEntity(arg0 uint64) (*link.Link, error) {
+func (m *MockRepo) DescribeEntity(arg0 context.Context, arg1 uint64) (*link.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DescribeEntity", arg0)
+	ret := m.ctrl.Call(m, "DescribeEntity", arg0, arg1)
 	ret0, _ := ret[0].(*link.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DescribeEntity indicates an expected call of DescribeEntity.
-func (mr *MockRepoMockRecorder) DescribeEntity(arg0 interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) DescribeEntity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeEntity", reflect.TypeOf((*MockRepo)(nil).DescribeEntity), arg0, arg1)
+}
+
+// ExistingCanonicalUrls mocks base method.
+func (m *MockRepo) ExistingCanonicalUrls(arg0 context.Context, arg1 uint64, arg2 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingCanonicalUrls", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingCanonicalUrls indicates an expected call of ExistingCanonicalUrls.
+func (mr *MockRepoMockRecorder) ExistingCanonicalUrls(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingCanonicalUrls", reflect.TypeOf((*MockRepo)(nil).ExistingCanonicalUrls), arg0, arg1, arg2)
+}
+
+// ListBrokenEntities mocks base method.
+func (m *MockRepo) ListBrokenEntities(arg0 context.Context, arg1, arg2, arg3 uint64) ([]link.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrokenEntities", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]link.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrokenEntities indicates an expected call of ListBrokenEntities.
+func (mr *MockRepoMockRecorder) ListBrokenEntities(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeEntity", reflect.TypeOf((*MockRepo)(nil).DescribeEntity), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrokenEntities", reflect.TypeOf((*MockRepo)(nil).ListBrokenEntities), arg0, arg1, arg2, arg3)
 }
 
 // ListEntities mocks base method.
-func (m *MockRepo) ListEntities(arg0, arg1 uint64) ([]link.Link, error) {
+func (m *MockRepo) ListEntities(arg0 context.Context, arg1 repo.Filter, arg2, arg3 uint64) ([]link.Link, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntities", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListEntities", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]link.Link)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListEntities indicates an expected call of ListEntities.
-func (mr *MockRepoMockRecorder) ListEntities(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockRepoMockRecorder) ListEntities(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntities", reflect.TypeOf((*MockRepo)(nil).ListEntities), arg0, arg1, arg2, arg3)
+}
+
+// ListEntitiesAfter mocks base method.
+func (m *MockRepo) ListEntitiesAfter(arg0 context.Context, arg1 repo.Filter, arg2 *repo.Cursor, arg3 uint64) ([]link.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntitiesAfter", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]link.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntitiesAfter indicates an expected call of ListEntitiesAfter.
+func (mr *MockRepoMockRecorder) ListEntitiesAfter(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntitiesAfter", reflect.TypeOf((*MockRepo)(nil).ListEntitiesAfter), arg0, arg1, arg2, arg3)
+}
+
+// ListEntitiesToCheck mocks base method.
+func (m *MockRepo) ListEntitiesToCheck(arg0 context.Context, arg1 time.Time, arg2, arg3 uint64) ([]link.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntitiesToCheck", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]link.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntitiesToCheck indicates an expected call of ListEntitiesToCheck.
+func (mr *MockRepoMockRecorder) ListEntitiesToCheck(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntitiesToCheck", reflect.TypeOf((*MockRepo)(nil).ListEntitiesToCheck), arg0, arg1, arg2, arg3)
+}
+
+// ListEntitiesToEnrich mocks base method.
+func (m *MockRepo) ListEntitiesToEnrich(arg0 context.Context, arg1 uint64) ([]link.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntitiesToEnrich", arg0, arg1)
+	ret0, _ := ret[0].([]link.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntitiesToEnrich indicates an expected call of ListEntitiesToEnrich.
+func (mr *MockRepoMockRecorder) ListEntitiesToEnrich(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntitiesToEnrich", reflect.TypeOf((*MockRepo)(nil).ListEntitiesToEnrich), arg0, arg1)
+}
+
+// ListTags mocks base method.
+func (m *MockRepo) ListTags(arg0 context.Context, arg1 uint64) ([]repo.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", arg0, arg1)
+	ret0, _ := ret[0].([]repo.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRepoMockRecorder) ListTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRepo)(nil).ListTags), arg0, arg1)
+}
+
+// MergeTags mocks base method.
+func (m *MockRepo) MergeTags(arg0 context.Context, arg1 uint64, arg2 []string, arg3 string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockRepoMockRecorder) MergeTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockRepo)(nil).MergeTags), arg0, arg1, arg2, arg3)
+}
+
+// PublishEvents mocks base method.
+func (m *MockRepo) PublishEvents(arg0 context.Context, arg1 uint64, arg2 func(context.Context, []event.Event) (uint64, error)) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishEvents indicates an expected call of PublishEvents.
+func (mr *MockRepoMockRecorder) PublishEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishEvents", reflect.TypeOf((*MockRepo)(nil).PublishEvents), arg0, arg1, arg2)
+}
+
+// PurgeDeletedEntities mocks base method.
+func (m *MockRepo) PurgeDeletedEntities(arg0 context.Context, arg1 time.Time) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedEntities", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedEntities indicates an expected call of PurgeDeletedEntities.
+func (mr *MockRepoMockRecorder) PurgeDeletedEntities(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedEntities", reflect.TypeOf((*MockRepo)(nil).PurgeDeletedEntities), arg0, arg1)
+}
+
+// RenameTag mocks base method.
+func (m *MockRepo) RenameTag(arg0 context.Context, arg1 uint64, arg2, arg3 string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockRepoMockRecorder) RenameTag(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockRepo)(nil).RenameTag), arg0, arg1, arg2, arg3)
+}
+
+// RestoreEntity mocks base method.
+func (m *MockRepo) RestoreEntity(arg0 context.Context, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreEntity", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreEntity indicates an expected call of RestoreEntity.
+func (mr *MockRepoMockRecorder) RestoreEntity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreEntity", reflect.TypeOf((*MockRepo)(nil).RestoreEntity), arg0, arg1)
+}
+
+// SearchEntities mocks base method.
+func (m *MockRepo) SearchEntities(arg0 context.Context, arg1 repo.SearchQuery, arg2 repo.Filter, arg3, arg4 uint64) ([]repo.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEntities", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]repo.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchEntities indicates an expected call of SearchEntities.
+func (mr *MockRepoMockRecorder) SearchEntities(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEntities", reflect.TypeOf((*MockRepo)(nil).SearchEntities), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateEntity mocks base method.
+func (m *MockRepo) UpdateEntity(arg0 context.Context, arg1 link.Link, arg2 []string) (*link.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*link.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEntity indicates an expected call of UpdateEntity.
+func (mr *MockRepoMockRecorder) UpdateEntity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntity", reflect.TypeOf((*MockRepo)(nil).UpdateEntity), arg0, arg1, arg2)
+}
+
+// UpdateEntityMetadata mocks base method.
+func (m *MockRepo) UpdateEntityMetadata(arg0 context.Context, arg1 uint64, arg2 string, arg3 link.Metadata) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntityMetadata", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEntityMetadata indicates an expected call of UpdateEntityMetadata.
+func (mr *MockRepoMockRecorder) UpdateEntityMetadata(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntityMetadata", reflect.TypeOf((*MockRepo)(nil).UpdateEntityMetadata), arg0, arg1, arg2, arg3)
+}
+
+// UpsertEntity mocks base method.
+func (m *MockRepo) UpsertEntity(arg0 context.Context, arg1 link.Link) (*link.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEntity", arg0, arg1)
+	ret0, _ := ret[0].(*link.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertEntity indicates an expected call of UpsertEntity.
+func (mr *MockRepoMockRecorder) UpsertEntity(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntities", reflect.TypeOf((*MockRepo)(nil).ListEntities), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEntity", reflect.TypeOf((*MockRepo)(nil).UpsertEntity), arg0, arg1)
 }
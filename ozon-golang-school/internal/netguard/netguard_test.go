@@ -0,0 +1,49 @@
+package netguard_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/netguard"
+)
+
+var _ = Describe("Netguard", func() {
+	DescribeTable("Public addresses",
+		func(address string, public bool) {
+			Expect(netguard.IsPublic(net.ParseIP(address))).Should(Equal(public))
+		},
+		Entry("public", "93.184.216.34", true),
+		Entry("public v6", "2606:2800:220:1:248:1893:25c8:1946", true),
+		Entry("loopback", "127.0.0.1", false),
+		Entry("loopback v6", "::1", false),
+		Entry("mapped loopback", "::ffff:127.0.0.1", false),
+		Entry("private", "10.1.2.3", false),
+		Entry("private 172", "172.20.0.1", false),
+		Entry("private 192", "192.168.1.1", false),
+		Entry("shared", "100.64.0.1", false),
+		Entry("metadata", "169.254.169.254", false),
+		Entry("unique local v6", "fd00::1", false),
+		Entry("link-local v6", "fe80::1", false),
+		Entry("unspecified", "0.0.0.0", false),
+		Entry("multicast", "224.0.0.1", false),
+	)
+
+	It("Control with an address that is not public. Should refuse the connection.", func() {
+		Expect(netguard.Control("tcp4", "169.254.169.254:80", nil)).Should(MatchError(netguard.ErrForbiddenAddress))
+		Expect(netguard.Control("tcp4", "93.184.216.34:443", nil)).Should(Succeed())
+	})
+
+	It("Request to a loopback server. Should be refused.", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		_, err := netguard.NewClient(time.Second).Get(server.URL)
+
+		Expect(err).Should(MatchError(netguard.ErrForbiddenAddress))
+	})
+})
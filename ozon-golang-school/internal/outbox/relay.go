@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/event"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"github.com/rs/zerolog"
+)
+
+type Relay interface {
+	Close()
+}
+
+type relay struct {
+	repo      repo.Repo
+	producer  event.Producer
+	logger    zerolog.Logger
+	batchSize uint64
+	period    time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan bool
+	closer    sync.Once
+}
+
+// NewRelay starts a worker that drains the outbox every period in batches of batchSize.
+// Events are sent one by one in outbox order and removed only after the producer
+// confirmed them, so delivery is at least once and events of a link keep their order.
+func NewRelay(
+	repo repo.Repo,
+	producer event.Producer,
+	logger zerolog.Logger,
+	batchSize uint64,
+	period time.Duration,
+) Relay {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &relay{
+		repo:      repo,
+		producer:  producer,
+		logger:    logger,
+		batchSize: batchSize,
+		period:    period,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan bool),
+	}
+
+	r.startWorker()
+	return r
+}
+
+func (r *relay) Close() {
+	r.closer.Do(func() {
+		r.cancel()
+		<-r.done
+	})
+}
+
+func (r *relay) startWorker() {
+	go func(r *relay) {
+		ticker := time.NewTicker(r.period)
+		defer ticker.Stop()
+
+		for r.ctx.Err() == nil {
+			r.relayAll()
+
+			select {
+			case <-ticker.C:
+			case <-r.ctx.Done():
+			}
+		}
+		close(r.done)
+	}(r)
+}
+
+func (r *relay) relayAll() {
+	for r.ctx.Err() == nil {
+		published, err := r.repo.PublishEvents(r.ctx, r.batchSize, r.publish)
+		if err != nil {
+			r.logger.Error().Err(err).Uint64("published", published).Msg("failed to relay outbox events")
+			return
+		}
+		if published < r.batchSize {
+			return
+		}
+	}
+}
+
+func (r *relay) publish(ctx context.Context, events []event.Event) (uint64, error) {
+	for i, e := range events {
+		if err := r.producer.Send(ctx, e); err != nil {
+			return uint64(i), err
+		}
+	}
+	return uint64(len(events)), nil
+}
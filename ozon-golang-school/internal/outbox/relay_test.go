@@ -0,0 +1,115 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/event"
+	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/ozonva/ova-link-api/internal/outbox"
+	"github.com/rs/zerolog"
+)
+
+// fakeOutbox mimics LinkRepo.PublishEvents over an in-memory table.
+type fakeOutbox struct {
+	mu     sync.Mutex
+	events []event.Event
+	calls  int
+}
+
+func (fo *fakeOutbox) publish(ctx context.Context, limit uint64, publish func(ctx context.Context, events []event.Event) (uint64, error)) (uint64, error) {
+	fo.mu.Lock()
+	defer fo.mu.Unlock()
+	fo.calls++
+
+	batch := fo.events
+	if uint64(len(batch)) > limit {
+		batch = batch[:limit]
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	published, err := publish(ctx, append([]event.Event(nil), batch...))
+	fo.events = fo.events[published:]
+	return published, err
+}
+
+func (fo *fakeOutbox) len() int {
+	fo.mu.Lock()
+	defer fo.mu.Unlock()
+	return len(fo.events)
+}
+
+func newEvents(linkIDs ...uint64) []event.Event {
+	events := make([]event.Event, 0, len(linkIDs))
+	for i, linkID := range linkIDs {
+		events = append(events, event.Event{ID: uint64(i + 1), LinkID: linkID, Type: event.LinkUpdated})
+	}
+	return events
+}
+
+var _ = Describe("Relay", func() {
+	var ctrl *gomock.Controller
+	var mockRepo *mocks.MockRepo
+	var producer *event.MemoryProducer
+	var store *fakeOutbox
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepo(ctrl)
+		producer = event.NewMemoryProducer()
+		store = &fakeOutbox{}
+		mockRepo.EXPECT().PublishEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(store.publish).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("Relay all events in order", func() {
+		store.events = newEvents(1, 2, 1, 3, 1)
+
+		relay := outbox.NewRelay(mockRepo, producer, zerolog.Nop(), 2, time.Hour)
+		Eventually(store.len).Should(BeZero())
+		relay.Close()
+
+		Expect(producer.Events()).Should(Equal(newEvents(1, 2, 1, 3, 1)))
+		// Two full batches and the last one with a single event, no waiting for the next tick.
+		Expect(store.calls).Should(Equal(3))
+	})
+
+	It("Retry events the producer failed to send", func() {
+		store.events = newEvents(1, 2, 1)
+		producer.SetError(errors.New("broker is down"))
+
+		relay := outbox.NewRelay(mockRepo, producer, zerolog.Nop(), 10, 10*time.Millisecond)
+		Eventually(func() int {
+			store.mu.Lock()
+			defer store.mu.Unlock()
+			return store.calls
+		}).Should(BeNumerically(">=", 2))
+		Expect(store.len()).Should(Equal(3))
+
+		producer.SetError(nil)
+		Eventually(store.len).Should(BeZero())
+		relay.Close()
+
+		Expect(producer.Events()).Should(Equal(newEvents(1, 2, 1)))
+	})
+
+	It("Close stops the relay", func() {
+		relay := outbox.NewRelay(mockRepo, producer, zerolog.Nop(), 10, time.Hour)
+		relay.Close()
+		relay.Close()
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		Expect(store.calls).Should(BeNumerically("<=", 1))
+	})
+})
@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ozonva/ova-link-api/internal/event"
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+const batchSavepoint = "add_entities"
+
+// RowError is a link of a batch the database rejected, Index points into the batch.
+type RowError struct {
+	Index int
+	Err   error
+}
+
+// BatchError reports the links AddEntities rejected, the rest of the batch is saved.
+type BatchError struct {
+	Rows []RowError
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d links of the batch are rejected, first at %d: %v", len(e.Rows), e.Rows[0].Index, e.Rows[0].Err)
+}
+
+// isRowError reports whether err is caused by the data of a row, so that retrying the same rows fails again.
+func isRowError(err error) bool {
+	return errors.Is(err, ErrInvalidInput) || errors.Is(err, ErrConflict)
+}
+
+// addEntitiesIsolated saves the batch in one transaction and isolates the links the database rejects.
+func (lp *LinkRepo) addEntitiesIsolated(ctx context.Context, entities []link.Link) error {
+	var rejected []RowError
+	err := lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		rejected = nil
+		inserted, err := insertBisecting(ctx, tx, entities, 0, &rejected)
+		if err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, event.LinkCreated, inserted...)
+	})
+	if err != nil {
+		return err
+	}
+	if len(rejected) > 0 {
+		return &BatchError{Rows: rejected}
+	}
+	return nil
+}
+
+// insertBisecting inserts entities under a savepoint. When a row is rejected it rolls back to the
+// savepoint and inserts both halves the same way, until the rejected links are left one by one.
+// A batch with k rejected links of n takes about 2k*log2(n) inserts.
+func insertBisecting(ctx context.Context, tx *sqlx.Tx, entities []link.Link, offset int, rejected *[]RowError) ([]*link.Link, error) {
+	if _, err := exec(ctx, tx, "SAVEPOINT "+batchSavepoint); err != nil {
+		return nil, err
+	}
+
+	inserted, err := insertEntities(ctx, tx, entities)
+	if err == nil {
+		_, err = exec(ctx, tx, "RELEASE SAVEPOINT "+batchSavepoint)
+		return inserted, err
+	}
+	if err = wrapError(err); !isRowError(err) {
+		return nil, err
+	}
+
+	if _, err := exec(ctx, tx, "ROLLBACK TO SAVEPOINT "+batchSavepoint); err != nil {
+		return nil, err
+	}
+	if _, err := exec(ctx, tx, "RELEASE SAVEPOINT "+batchSavepoint); err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 1 {
+		*rejected = append(*rejected, RowError{Index: offset, Err: err})
+		return nil, nil
+	}
+
+	middle := len(entities) / 2
+	left, err := insertBisecting(ctx, tx, entities[:middle], offset, rejected)
+	if err != nil {
+		return nil, err
+	}
+	right, err := insertBisecting(ctx, tx, entities[middle:], offset+middle, rejected)
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
@@ -0,0 +1,144 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+// insertLinks matches the multi-row insert of AddEntities with count links.
+func insertLinks(count int) string {
+	values := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		values = append(values, "\\(\\$"+strconv.Itoa(4*i+1)+",\\$"+strconv.Itoa(4*i+2)+",\\$"+strconv.Itoa(4*i+3)+",\\$"+strconv.Itoa(4*i+4)+",DEFAULT\\)")
+	}
+	return "^INSERT INTO links \\(user_id,url,canonical_url,description,created_at\\) VALUES " + strings.Join(values, ",") + " ON CONFLICT"
+}
+
+var _ = Describe("Batch", func() {
+	var linkRepo repo.Repo
+	var dbMock sqlxmock.Sqlmock
+	var ctx context.Context
+	var entities []link.Link
+	var rejected error
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		db, mock, err := sqlxmock.Newx()
+		Expect(err).ShouldNot(HaveOccurred())
+		dbMock = mock
+		linkRepo = repo.NewLinkRepo(db, repo.Timeouts{})
+
+		entities = make([]link.Link, 0, 4)
+		for i := 1; i <= 4; i++ {
+			url := "https://test.com" + strconv.Itoa(i)
+			entities = append(entities, link.Link{UserID: 1, Url: url, CanonicalUrl: url})
+		}
+		rejected = pgx.PgError{Code: "22021", Message: "invalid byte sequence for encoding \"UTF8\": 0x00"}
+	})
+
+	expectSavepoint := func(count int, ids ...int) {
+		dbMock.ExpectExec("^SAVEPOINT add_entities$").WillReturnResult(sqlxmock.NewResult(0, 0))
+		if len(ids) == 0 {
+			dbMock.ExpectQuery(insertLinks(count)).WillReturnError(rejected)
+			dbMock.ExpectExec("^ROLLBACK TO SAVEPOINT add_entities$").WillReturnResult(sqlxmock.NewResult(0, 0))
+			dbMock.ExpectExec("^RELEASE SAVEPOINT add_entities$").WillReturnResult(sqlxmock.NewResult(0, 0))
+			return
+		}
+
+		rows := sqlxmock.NewRows([]string{"id", "user_id", "canonical_url"})
+		for _, id := range ids {
+			rows.AddRow(id, 1, "https://test.com"+strconv.Itoa(id))
+		}
+		dbMock.ExpectQuery(insertLinks(count)).WillReturnRows(rows)
+		dbMock.ExpectExec("^RELEASE SAVEPOINT add_entities$").WillReturnResult(sqlxmock.NewResult(0, 0))
+	}
+
+	It("Create with a rejected link. Should save the rest and report the rejected one.", func() {
+		dbMock.ExpectBegin()
+		dbMock.ExpectQuery(insertLinks(4)).WillReturnError(rejected)
+		dbMock.ExpectRollback()
+
+		dbMock.ExpectBegin()
+		expectSavepoint(4)
+		expectSavepoint(2, 1, 2)
+		expectSavepoint(2)
+		expectSavepoint(1, 3)
+		expectSavepoint(1)
+		dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\),\\(\\$4,\\$5,\\$6\\),\\(\\$7,\\$8,\\$9\\)").
+			WithArgs(1, "LinkCreated", sqlxmock.AnyArg(), 2, "LinkCreated", sqlxmock.AnyArg(), 3, "LinkCreated", sqlxmock.AnyArg()).
+			WillReturnResult(sqlxmock.NewResult(0, 3))
+		dbMock.ExpectCommit()
+
+		err := linkRepo.AddEntities(ctx, entities)
+
+		var batchErr *repo.BatchError
+		Expect(errors.As(err, &batchErr)).Should(BeTrue())
+		Expect(batchErr.Rows).Should(HaveLen(1))
+		Expect(batchErr.Rows[0].Index).Should(Equal(3))
+		Expect(batchErr.Rows[0].Err).Should(MatchError(repo.ErrInvalidInput))
+		Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+	})
+
+	It("Create with every link rejected. Should save nothing and report all of them.", func() {
+		dbMock.ExpectBegin()
+		dbMock.ExpectQuery(insertLinks(2)).WillReturnError(rejected)
+		dbMock.ExpectRollback()
+
+		dbMock.ExpectBegin()
+		expectSavepoint(2)
+		expectSavepoint(1)
+		expectSavepoint(1)
+		dbMock.ExpectCommit()
+
+		err := linkRepo.AddEntities(ctx, entities[:2])
+
+		var batchErr *repo.BatchError
+		Expect(errors.As(err, &batchErr)).Should(BeTrue())
+		Expect(batchErr.Rows).Should(HaveLen(2))
+		Expect(batchErr.Rows[0].Index).Should(Equal(0))
+		Expect(batchErr.Rows[1].Index).Should(Equal(1))
+		Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+	})
+
+	It("Storage fails while isolating links. Should fail the whole batch.", func() {
+		dbMock.ExpectBegin()
+		dbMock.ExpectQuery(insertLinks(2)).WillReturnError(rejected)
+		dbMock.ExpectRollback()
+
+		dbMock.ExpectBegin()
+		expectSavepoint(2)
+		dbMock.ExpectExec("^SAVEPOINT add_entities$").WillReturnResult(sqlxmock.NewResult(0, 0))
+		dbMock.ExpectQuery(insertLinks(1)).WillReturnError(pgx.PgError{Code: "57P01", Message: "terminating connection"})
+		dbMock.ExpectRollback()
+
+		err := linkRepo.AddEntities(ctx, entities[:2])
+
+		Expect(err).Should(MatchError(repo.ErrUnavailable))
+		Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+	})
+
+	It("Create over the copy threshold without pgx. Should insert links with a multi-row insert.", func() {
+		db, mock, err := sqlxmock.Newx()
+		Expect(err).ShouldNot(HaveOccurred())
+		copyRepo := repo.NewLinkRepo(db, repo.Timeouts{})
+		copyRepo.SetCopyThreshold(2)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(insertLinks(2)).
+			WillReturnRows(sqlxmock.NewRows([]string{"id", "user_id", "canonical_url"}).AddRow(1, 1, "https://test.com1"))
+		mock.ExpectExec(insertOutbox + "\\(\\$1,\\$2,\\$3\\)").WillReturnResult(sqlxmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		Expect(copyRepo.AddEntities(ctx, entities[:2])).Should(Succeed())
+		Expect(mock.ExpectationsWereMet()).Should(Succeed())
+	})
+})
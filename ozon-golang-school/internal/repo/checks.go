@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"context"
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+// keptChecks is the number of latest checks kept for every link, older ones are dropped as new ones are added.
+const keptChecks = 30
+
+var checkColumns = []string{"link_id", "status_code", "final_url", "error", "checked_at"}
+
+const lastCheck = "LATERAL (SELECT status_code, error, checked_at FROM link_checks " +
+	"WHERE link_checks.link_id = links.id ORDER BY checked_at DESC LIMIT 1) c ON true"
+
+func loadLastChecks(ctx context.Context, q sqlx.QueryerContext, entities ...*link.Link) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.ID)
+	}
+
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(checkColumns...).
+		Options("DISTINCT ON (link_id)").
+		From("link_checks").
+		Where(squirrel.Eq{"link_id": ids}).
+		OrderBy("link_id", "checked_at DESC").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows := make([]link.Check, 0, len(entities))
+	if err := selectRows(ctx, q, &rows, sql, params...); err != nil {
+		return err
+	}
+
+	checks := make(map[uint64]*link.Check, len(rows))
+	for i := range rows {
+		checks[rows[i].LinkID] = &rows[i]
+	}
+	for _, entity := range entities {
+		entity.LastCheck = checks[entity.ID]
+	}
+
+	return nil
+}
+
+func pruneChecks(ctx context.Context, tx sqlx.ExecerContext, linkIDs []uint64) error {
+	positions, params, err := squirrel.
+		Select("id", "row_number() OVER (PARTITION BY link_id ORDER BY checked_at DESC) AS position").
+		From("link_checks").
+		Where(squirrel.Eq{"link_id": linkIDs}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Delete("link_checks").
+		Where("id IN (SELECT id FROM ("+positions+") c WHERE position > ?)", append(params, keptChecks)...).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = exec(ctx, tx, sql, params...)
+	return err
+}
@@ -0,0 +1,178 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/jackc/pgx"
+	"github.com/jackc/pgx/stdlib"
+	"github.com/ozonva/ova-link-api/internal/event"
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+// DefaultCopyThreshold is the batch size from which AddEntities writes links with COPY.
+const DefaultCopyThreshold = 1000
+
+const copyTable = "links_copy"
+
+var copyColumns = []string{"user_id", "url", "canonical_url", "description", "created_at"}
+
+// Rows are checked by the INSERT from the copy table, so that a rejected row can be isolated by AddEntities.
+const createCopyTable = "CREATE TEMPORARY TABLE " + copyTable +
+	" (user_id int8, url text, canonical_url text, description text, created_at timestamptz) ON COMMIT DROP"
+
+const insertFromCopy = "INSERT INTO links (user_id, url, canonical_url, description, created_at) " +
+	"SELECT user_id, url, canonical_url, description, coalesce(created_at, now()) FROM " + copyTable + " " +
+	onDuplicateUrl + " RETURNING id, user_id, canonical_url"
+
+// SetCopyThreshold sets the batch size from which AddEntities writes links with COPY, zero turns COPY off.
+func (lp *LinkRepo) SetCopyThreshold(threshold int) {
+	lp.copyThreshold = threshold
+}
+
+// canCopy reports whether the pool is pgx, COPY is not available through database/sql.
+func (lp *LinkRepo) canCopy() bool {
+	_, ok := lp.db.Driver().(*stdlib.Driver)
+	return ok
+}
+
+// copyEntities streams links with COPY into a temporary table and moves them into links with a
+// single INSERT, which skips duplicates like the multi-row one. Tags and events are saved in the
+// same transaction, it runs on a pgx connection taken from the pool.
+func (lp *LinkRepo) copyEntities(ctx context.Context, entities []link.Link) error {
+	conn, err := stdlib.AcquireConn(lp.db.DB)
+	if err != nil {
+		return wrapError(err)
+	}
+	defer func() { _ = stdlib.ReleaseConn(lp.db.DB, conn) }()
+
+	tx, err := conn.BeginEx(ctx, nil)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if err := copyLinks(ctx, tx, entities); err != nil {
+		_ = tx.Rollback()
+		return wrapError(err)
+	}
+
+	_, span := startQuerySpan(ctx, "COMMIT")
+	err = tx.CommitEx(ctx)
+	finishSpan(span, err)
+	return wrapError(err)
+}
+
+func copyLinks(ctx context.Context, tx *pgx.Tx, entities []link.Link) error {
+	if err := pgxExec(ctx, tx, createCopyTable); err != nil {
+		return err
+	}
+
+	rows := make([][]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		// NULL takes the default creation time.
+		var createdAt interface{}
+		if !entity.CreatedAt.IsZero() {
+			createdAt = entity.CreatedAt
+		}
+		rows = append(rows, []interface{}{int64(entity.UserID), entity.Url, entity.CanonicalUrl, entity.Description, createdAt})
+	}
+
+	_, span := startQuerySpan(ctx, "COPY "+copyTable)
+	copied, err := tx.CopyFrom(pgx.Identifier{copyTable}, copyColumns, pgx.CopyFromRows(rows))
+	if err == nil {
+		span.SetAttributes(RowsKey.Int(copied))
+	}
+	finishSpan(span, err)
+	if err != nil {
+		return err
+	}
+
+	saved := make([]link.Link, 0, len(entities))
+	err = pgxQuery(ctx, tx, insertFromCopy, nil, func(rows *pgx.Rows) error {
+		var id, userID int64
+		var canonicalUrl string
+		if err := rows.Scan(&id, &userID, &canonicalUrl); err != nil {
+			return err
+		}
+		saved = append(saved, link.Link{ID: uint64(id), UserID: uint64(userID), CanonicalUrl: canonicalUrl})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	inserted := matchInserted(entities, saved)
+	if err := pgxSaveTags(ctx, tx, inserted); err != nil {
+		return err
+	}
+	if len(inserted) == 0 {
+		return nil
+	}
+
+	sql, params, err := addEventsQuery(event.LinkCreated, inserted)
+	if err != nil {
+		return err
+	}
+	return pgxExec(ctx, tx, sql, params...)
+}
+
+func pgxSaveTags(ctx context.Context, tx *pgx.Tx, entities []*link.Link) error {
+	sql, params, err := upsertTagsQuery(entities)
+	if err != nil || sql == "" {
+		return err
+	}
+
+	tagIDs := make([]tagID, 0, len(params))
+	err = pgxQuery(ctx, tx, sql, params, func(rows *pgx.Rows) error {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		tagIDs = append(tagIDs, tagID{ID: uint64(id), Name: name})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sql, params, err = linkTagsQuery(entities, tagIDs)
+	if err != nil {
+		return err
+	}
+	return pgxExec(ctx, tx, sql, params...)
+}
+
+func pgxExec(ctx context.Context, tx *pgx.Tx, query string, params ...interface{}) error {
+	ctx, span := startQuerySpan(ctx, query)
+	tag, err := tx.ExecEx(ctx, query, nil, params...)
+	if err == nil {
+		span.SetAttributes(RowsKey.Int64(tag.RowsAffected()))
+	}
+	finishSpan(span, err)
+	return err
+}
+
+func pgxQuery(ctx context.Context, tx *pgx.Tx, query string, params []interface{}, scan func(rows *pgx.Rows) error) (err error) {
+	ctx, span := startQuerySpan(ctx, query)
+	count := 0
+	defer func() {
+		if err == nil {
+			span.SetAttributes(RowsKey.Int(count))
+		}
+		finishSpan(span, err)
+	}()
+
+	rows, err := tx.QueryEx(ctx, query, nil, params...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+		count++
+	}
+	return rows.Err()
+}
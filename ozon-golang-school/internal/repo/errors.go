@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx"
+)
+
+var (
+	ErrNotFound     = errors.New("entity not found")
+	ErrConflict     = errors.New("entity already exists")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrUnavailable  = errors.New("storage is unavailable")
+)
+
+type Error struct {
+	Kind  error
+	Field string
+	ID    uint64
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%v: %s: %v", e.Kind, e.Field, e.Err)
+	}
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func invalidInput(field string, err error) error {
+	return &Error{Kind: ErrInvalidInput, Field: field, Err: err}
+}
+
+func notFound(entityId uint64) error {
+	return &Error{Kind: ErrNotFound, Err: fmt.Errorf("link %d", entityId)}
+}
+
+func duplicate(existingID uint64) error {
+	return &Error{Kind: ErrConflict, Field: "url", ID: existingID, Err: fmt.Errorf("link %d has the same url", existingID)}
+}
+
+func tagNotFound(names ...string) error {
+	return &Error{Kind: ErrNotFound, Err: fmt.Errorf("tag %s", strings.Join(names, ", "))}
+}
+
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var repoErr *Error
+	if errors.As(err, &repoErr) {
+		return err
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Error{Kind: ErrNotFound, Err: err}
+	}
+
+	var pgErr pgx.PgError
+	if errors.As(err, &pgErr) {
+		if kind := sqlStateKind(pgErr.Code); kind != nil {
+			return &Error{Kind: kind, Field: pgErr.ColumnName, Err: err}
+		}
+		return err
+	}
+
+	// A deadline of the caller is not a failure of the storage, it is returned as is.
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, pgx.ErrDeadConn) ||
+		errors.As(err, &netErr) {
+		return &Error{Kind: ErrUnavailable, Err: err}
+	}
+
+	return err
+}
+
+func sqlStateKind(code string) error {
+	switch {
+	case code == "23505":
+		return ErrConflict
+	case strings.HasPrefix(code, "22"), strings.HasPrefix(code, "23"):
+		return ErrInvalidInput
+	case strings.HasPrefix(code, "08"), strings.HasPrefix(code, "53"), strings.HasPrefix(code, "57"):
+		return ErrUnavailable
+	default:
+		return nil
+	}
+}
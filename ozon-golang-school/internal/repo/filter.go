@@ -0,0 +1,77 @@
+package repo
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type Filter struct {
+	UserID       uint64
+	Tags         []string
+	MatchAllTags bool
+	Search       string
+	CreatedFrom  time.Time
+	CreatedTo    time.Time
+	OnlyDeleted  bool
+}
+
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uint64
+}
+
+const linkHasTags = "EXISTS (SELECT 1 FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = links.id AND t.name"
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (f Filter) where() squirrel.And {
+	where := squirrel.And{}
+
+	if f.OnlyDeleted {
+		where = append(where, squirrel.NotEq{"deleted_at": nil})
+	} else {
+		where = append(where, squirrel.Eq{"deleted_at": nil})
+	}
+
+	if f.UserID != 0 {
+		where = append(where, squirrel.Eq{"user_id": f.UserID})
+	}
+
+	if len(f.Tags) > 0 {
+		if f.MatchAllTags {
+			for _, tag := range f.Tags {
+				where = append(where, squirrel.Expr(linkHasTags+" = ?)", tag))
+			}
+		} else {
+			where = append(where, hasAnyTag(f.Tags))
+		}
+	}
+
+	if f.Search != "" {
+		pattern := "%" + likeEscaper.Replace(f.Search) + "%"
+		where = append(where, squirrel.Or{
+			squirrel.ILike{"url": pattern},
+			squirrel.ILike{"description": pattern},
+		})
+	}
+
+	if !f.CreatedFrom.IsZero() {
+		where = append(where, squirrel.GtOrEq{"created_at": f.CreatedFrom})
+	}
+
+	if !f.CreatedTo.IsZero() {
+		where = append(where, squirrel.Lt{"created_at": f.CreatedTo})
+	}
+
+	return where
+}
+
+func hasAnyTag(tags []string) squirrel.Sqlizer {
+	params := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		params = append(params, tag)
+	}
+	return squirrel.Expr(linkHasTags+" IN ("+squirrel.Placeholders(len(tags))+"))", params...)
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozonva/ova-link-api/internal/event"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var eventColumns = []string{"id", "link_id", "event_type", "payload", "created_at"}
+
+const eventsChunkSize = 1000
+
+// addEvents writes change events into the outbox within the transaction of the change itself.
+// Metadata written by the enricher produces no events.
+func addEvents(ctx context.Context, tx sqlx.ExecerContext, eventType event.Type, entities ...*link.Link) error {
+	for len(entities) > 0 {
+		// A purge may return more links than the placeholders of one statement allow.
+		n := len(entities)
+		if n > eventsChunkSize {
+			n = eventsChunkSize
+		}
+
+		sql, params, err := addEventsQuery(eventType, entities[:n])
+		if err != nil {
+			return err
+		}
+		if _, err := exec(ctx, tx, sql, params...); err != nil {
+			return err
+		}
+		entities = entities[n:]
+	}
+	return nil
+}
+
+func addEventsQuery(eventType event.Type, entities []*link.Link) (string, []interface{}, error) {
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("outbox").
+		Columns("link_id", "event_type", "payload")
+	for _, entity := range entities {
+		payload, err := event.NewLinkPayload(entity)
+		if err != nil {
+			return "", nil, err
+		}
+		sqlBuilder = sqlBuilder.Values(entity.ID, string(eventType), string(payload))
+	}
+	return sqlBuilder.ToSql()
+}
+
+// outboxLockKey is the advisory lock that lets one caller of PublishEvents at a time read the outbox.
+const outboxLockKey = 0x6f7574626f78
+
+// PublishEvents hands up to limit oldest outbox events to publish in order. publish returns
+// how many leading events it has published, only those are removed, so the rest is retried
+// first on the next call. Sends happen outside of any transaction and hold no row locks, a
+// session advisory lock keeps concurrent callers from publishing the same events out of
+// order, a caller that does not get it publishes nothing.
+func (lp *LinkRepo) PublishEvents(ctx context.Context, limit uint64, publish func(ctx context.Context, events []event.Event) (uint64, error)) (_ uint64, err error) {
+	ctx, finish := lp.startOperation(ctx, "PublishEvents", lp.timeouts.Batch)
+	defer func() { err = finish(err) }()
+
+	conn, err := lp.db.Connx(ctx)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := getRow(ctx, conn, &locked, "SELECT pg_try_advisory_lock($1)", outboxLockKey); err != nil {
+		return 0, wrapError(err)
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer lp.unlockOutbox(ctx, conn)
+
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(eventColumns...).
+		From("outbox").
+		OrderBy("id").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	events := make([]event.Event, 0, limit)
+	if err := selectRows(ctx, conn, &events, sql, params...); err != nil {
+		return 0, wrapError(err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	published, publishErr := publish(ctx, events)
+	if published == 0 {
+		return 0, publishErr
+	}
+
+	ids := make([]uint64, 0, published)
+	for _, published := range events[:published] {
+		ids = append(ids, published.ID)
+	}
+	deleteSql, deleteParams, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Delete("outbox").
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	// Sent events are removed even when ctx is done by now, they would be sent again otherwise.
+	deleteCtx, cancel := detachedContext(ctx, lp.timeouts.Write)
+	defer cancel()
+	if _, err := exec(deleteCtx, conn, deleteSql, deleteParams...); err != nil {
+		return 0, wrapError(err)
+	}
+
+	return published, publishErr
+}
+
+// unlockOutbox releases the advisory lock before conn goes back to the pool. A connection
+// that may still hold it is closed instead, otherwise no one would publish events again.
+func (lp *LinkRepo) unlockOutbox(ctx context.Context, conn *sqlx.Conn) {
+	ctx, cancel := detachedContext(ctx, lp.timeouts.Write)
+	defer cancel()
+
+	var unlocked bool
+	if err := getRow(ctx, conn, &unlocked, "SELECT pg_advisory_unlock($1)", outboxLockKey); err != nil || !unlocked {
+		_ = conn.Raw(func(driverConn interface{}) error {
+			return driver.ErrBadConn
+		})
+	}
+}
+
+// detachedContext keeps the span of ctx but not its deadline or cancellation.
+func detachedContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	detached := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+	if timeout > 0 {
+		return context.WithTimeout(detached, timeout)
+	}
+	return context.WithCancel(detached)
+}
@@ -1,52 +1,245 @@
 package repo
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/Masterminds/squirrel"
 	_ "github.com/jackc/pgx/stdlib"
 	"github.com/jmoiron/sqlx"
+	"github.com/ozonva/ova-link-api/internal/event"
 	"github.com/ozonva/ova-link-api/internal/link"
 )
 
 type Repo interface {
-	AddEntities(entities []link.Link) error
-	ListEntities(limit uint64, offset uint64) ([]link.Link, error)
-	DescribeEntity(entityId uint64) (*link.Link, error)
-	DeleteEntity(entityId uint64) error
+	AddEntity(ctx context.Context, entity link.Link) (*link.Link, error)
+	AddEntities(ctx context.Context, entities []link.Link) error
+	ListEntities(ctx context.Context, filter Filter, limit uint64, offset uint64) ([]link.Link, error)
+	ListEntitiesAfter(ctx context.Context, filter Filter, cursor *Cursor, limit uint64) ([]link.Link, error)
+	CountEntities(ctx context.Context, filter Filter) (uint64, error)
+	DescribeEntity(ctx context.Context, entityId uint64) (*link.Link, error)
+	DeleteEntity(ctx context.Context, entityId uint64) error
+	RestoreEntity(ctx context.Context, entityId uint64) error
+	PurgeDeletedEntities(ctx context.Context, olderThan time.Time) (uint64, error)
+	UpsertEntity(ctx context.Context, entity link.Link) (*link.Link, error)
+	UpdateEntity(ctx context.Context, entity link.Link, fields []string) (*link.Link, error)
+	ListEntitiesToEnrich(ctx context.Context, limit uint64) ([]link.Link, error)
+	UpdateEntityMetadata(ctx context.Context, entityId uint64, url string, metadata link.Metadata) error
+	ListEntitiesToCheck(ctx context.Context, checkedBefore time.Time, afterID uint64, limit uint64) ([]link.Link, error)
+	AddChecks(ctx context.Context, checks []link.Check) error
+	ListBrokenEntities(ctx context.Context, userID uint64, limit uint64, offset uint64) ([]link.Link, error)
+	SearchEntities(ctx context.Context, query SearchQuery, filter Filter, limit uint64, offset uint64) ([]SearchResult, error)
+	ExistingCanonicalUrls(ctx context.Context, userID uint64, canonicalUrls []string) ([]string, error)
+	PublishEvents(ctx context.Context, limit uint64, publish func(ctx context.Context, events []event.Event) (uint64, error)) (uint64, error)
+	ListTags(ctx context.Context, userID uint64) ([]TagCount, error)
+	RenameTag(ctx context.Context, userID uint64, name string, newName string) (uint64, error)
+	MergeTags(ctx context.Context, userID uint64, names []string, target string) (uint64, error)
+}
+
+var linkColumns = []string{
+	"id", "user_id", "url", "canonical_url", "description", "created_at", "updated_at", "deleted_at",
+	"title", "page_description", "image_url", "favicon_url", "page_canonical_url", "enriched_at",
+}
+
+const onDuplicateUrl = "ON CONFLICT (user_id, canonical_url) WHERE deleted_at IS NULL DO NOTHING"
+
+// Timeouts bound a single repo call by the kind of its work, zero leaves only the deadline of the caller.
+type Timeouts struct {
+	// Read is for lookups and listings.
+	Read time.Duration
+	// Write is for changes of a single link.
+	Write time.Duration
+	// Batch is for changes of many links and rows at once.
+	Batch time.Duration
 }
 
 type LinkRepo struct {
-	db *sqlx.DB
+	db            *sqlx.DB
+	timeouts      Timeouts
+	copyThreshold int
 }
 
-func NewLinkRepo(db *sqlx.DB) *LinkRepo {
+func NewLinkRepo(db *sqlx.DB, timeouts Timeouts) *LinkRepo {
 	return &LinkRepo{
-		db: db,
+		db:            db,
+		timeouts:      timeouts,
+		copyThreshold: DefaultCopyThreshold,
+	}
+}
+
+func (lp *LinkRepo) AddEntity(ctx context.Context, entity link.Link) (_ *link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "AddEntity", lp.timeouts.Write)
+	defer func() { err = finish(err) }()
+
+	sql, params, err := insertLink(entity).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &link.Link{}
+	err = lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		inserted, err := getInserted(ctx, tx, result, sql, params...)
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			existing, err := findDuplicate(ctx, tx, entity, false)
+			if err != nil {
+				return err
+			}
+			return duplicate(existing.ID)
+		}
+		result.Tags = entity.GetTagsAsSlice()
+		if err := saveTags(ctx, tx, result); err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, event.LinkCreated, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (lp *LinkRepo) UpsertEntity(ctx context.Context, entity link.Link) (_ *link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "UpsertEntity", lp.timeouts.Write)
+	defer func() { err = finish(err) }()
+
+	sql, params, err := insertLink(entity).ToSql()
+	if err != nil {
+		return nil, err
 	}
+
+	result := &link.Link{}
+	err = lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		inserted, err := getInserted(ctx, tx, result, sql, params...)
+		if err != nil {
+			return err
+		}
+		if inserted {
+			result.Tags = entity.GetTagsAsSlice()
+			if err := saveTags(ctx, tx, result); err != nil {
+				return err
+			}
+			return addEvents(ctx, tx, event.LinkCreated, result)
+		}
+
+		existing, err := findDuplicate(ctx, tx, entity, true)
+		if err != nil {
+			return err
+		}
+		if err := loadTags(ctx, tx, existing); err != nil {
+			return err
+		}
+		*result = *existing
+		for _, tag := range entity.GetTagsAsSlice() {
+			result.AddTag(tag)
+		}
+		if len(result.Tags) == len(existing.Tags) {
+			return nil
+		}
+		if err := saveTags(ctx, tx, result); err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, event.LinkUpdated, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// AddEntities saves links skipping the ones that duplicate saved links. Links the database rejects
+// are isolated from the rest of the batch, which is saved, and reported by *BatchError.
+func (lp *LinkRepo) AddEntities(ctx context.Context, entities []link.Link) (err error) {
+	ctx, finish := lp.startOperation(ctx, "AddEntities", lp.timeouts.Batch)
+	defer func() { err = finish(err) }()
+
+	if lp.copyThreshold > 0 && len(entities) >= lp.copyThreshold && lp.canCopy() {
+		err = lp.copyEntities(ctx, entities)
+	} else {
+		err = lp.withTx(ctx, func(tx *sqlx.Tx) error {
+			inserted, err := insertEntities(ctx, tx, entities)
+			if err != nil {
+				return err
+			}
+			return addEvents(ctx, tx, event.LinkCreated, inserted...)
+		})
+	}
+	if isRowError(err) {
+		return lp.addEntitiesIsolated(ctx, entities)
+	}
+	return err
 }
 
-func (lp *LinkRepo) AddEntities(entities []link.Link) error {
+// insertEntities inserts links with their tags and returns the ones that are not duplicates.
+func insertEntities(ctx context.Context, tx sqlx.ExtContext, entities []link.Link) ([]*link.Link, error) {
 	sqlBuilder := squirrel.StatementBuilder.
 		PlaceholderFormat(squirrel.Dollar).
 		Insert("links").
-		Columns("user_id", "url", "description", "tags")
+		Columns("user_id", "url", "canonical_url", "description", "created_at").
+		Suffix(onDuplicateUrl + " RETURNING id, user_id, canonical_url")
 
 	for _, entity := range entities {
-		sqlBuilder = sqlBuilder.Values(entity.UserID, entity.Url, entity.Description, entity.Tags)
+		sqlBuilder = sqlBuilder.Values(entity.UserID, entity.Url, entity.CanonicalUrl, entity.Description, createdAt(entity))
 	}
 
-	_, err := sqlBuilder.RunWith(lp.db).Exec()
+	sql, params, err := sqlBuilder.ToSql()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	rows := make([]link.Link, 0, len(entities))
+	if err := selectRows(ctx, tx, &rows, sql, params...); err != nil {
+		return nil, err
+	}
+
+	inserted := matchInserted(entities, rows)
+	if err := saveTags(ctx, tx, inserted...); err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+// matchInserted sets the ids of the returned rows to their entities, links that duplicate already saved ones are skipped.
+func matchInserted(entities []link.Link, rows []link.Link) []*link.Link {
+	ids := make(map[duplicateKey]uint64, len(rows))
+	for _, row := range rows {
+		ids[duplicateKey{row.UserID, row.CanonicalUrl}] = row.ID
+	}
+
+	inserted := make([]*link.Link, 0, len(rows))
+	for i := range entities {
+		key := duplicateKey{entities[i].UserID, entities[i].CanonicalUrl}
+		id, ok := ids[key]
+		if !ok {
+			continue
+		}
+		delete(ids, key)
+
+		entity := entities[i]
+		entity.ID = id
+		inserted = append(inserted, &entity)
+	}
+	return inserted
 }
 
-func (lp *LinkRepo) ListEntities(limit uint64, offset uint64) ([]link.Link, error) {
+func (lp *LinkRepo) ListEntities(ctx context.Context, filter Filter, limit uint64, offset uint64) (_ []link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "ListEntities", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
 	sqlBuilder := squirrel.StatementBuilder.
 		PlaceholderFormat(squirrel.Dollar).
-		Select("id", "user_id", "url", "description", "tags", "created_at").
+		Select(linkColumns...).
 		From("links").
+		Where(filter.where()).
+		OrderBy("id").
 		Limit(limit).Offset(offset)
 
 	sql, params, err := sqlBuilder.ToSql()
@@ -55,20 +248,87 @@ func (lp *LinkRepo) ListEntities(limit uint64, offset uint64) ([]link.Link, erro
 	}
 
 	result := make([]link.Link, 0, 0)
-	err = lp.db.Select(&result, sql, params...)
+	err = selectRows(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if err := loadTags(ctx, lp.db, linkPointers(result)...); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+func (lp *LinkRepo) ListEntitiesAfter(ctx context.Context, filter Filter, cursor *Cursor, limit uint64) (_ []link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "ListEntitiesAfter", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(linkColumns...).
+		From("links").
+		OrderBy("created_at", "id").
+		Limit(limit)
+
+	where := filter.where()
+	if cursor != nil {
+		where = append(where, squirrel.Expr("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID))
+	}
+	sqlBuilder = sqlBuilder.Where(where)
+
+	sql, params, err := sqlBuilder.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
+	result := make([]link.Link, 0, limit)
+	err = selectRows(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if err := loadTags(ctx, lp.db, linkPointers(result)...); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+func (lp *LinkRepo) CountEntities(ctx context.Context, filter Filter) (_ uint64, err error) {
+	ctx, finish := lp.startOperation(ctx, "CountEntities", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select("count(*)").
+		From("links").
+		Where(filter.where())
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var result uint64
+	err = getRow(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
 	return result, nil
 }
 
-func (lp *LinkRepo) DescribeEntity(entityId uint64) (*link.Link, error) {
+func (lp *LinkRepo) DescribeEntity(ctx context.Context, entityId uint64) (_ *link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "DescribeEntity", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
 	sqlBuilder := squirrel.StatementBuilder.
 		PlaceholderFormat(squirrel.Dollar).
-		Select("id", "user_id", "url", "description", "tags", "created_at").
+		Select(linkColumns...).
 		From("links").
-		Where("id = ?")
+		Where("id = ?").
+		Where("deleted_at IS NULL")
 
 	sql, _, err := sqlBuilder.ToSql()
 	if err != nil {
@@ -76,29 +336,593 @@ func (lp *LinkRepo) DescribeEntity(entityId uint64) (*link.Link, error) {
 	}
 
 	result := &link.Link{}
-	err = lp.db.Get(result, sql, entityId)
+	err = getRow(ctx, lp.db, result, sql, entityId)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
+	}
+
+	if err := loadTags(ctx, lp.db, result); err != nil {
+		return nil, wrapError(err)
+	}
+	if err := loadLastChecks(ctx, lp.db, result); err != nil {
+		return nil, wrapError(err)
 	}
 
 	return result, nil
 }
 
-func (lp *LinkRepo) DeleteEntity(entityId uint64) error {
+func (lp *LinkRepo) DeleteEntity(ctx context.Context, entityId uint64) (err error) {
+	ctx, finish := lp.startOperation(ctx, "DeleteEntity", lp.timeouts.Write)
+	defer func() { err = finish(err) }()
+
 	sqlBuilder := squirrel.StatementBuilder.
 		PlaceholderFormat(squirrel.Dollar).
-		Delete("links").
-		Where("id = ?")
+		Update("links").
+		Set("deleted_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": entityId, "deleted_at": nil})
 
-	sql, _, err := sqlBuilder.ToSql()
+	return lp.changeDeleted(ctx, sqlBuilder, entityId, event.LinkDeleted)
+}
+
+func (lp *LinkRepo) RestoreEntity(ctx context.Context, entityId uint64) (err error) {
+	ctx, finish := lp.startOperation(ctx, "RestoreEntity", lp.timeouts.Write)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("links").
+		Set("deleted_at", nil).
+		Where(squirrel.Eq{"id": entityId}).
+		Where(squirrel.NotEq{"deleted_at": nil})
+
+	return lp.changeDeleted(ctx, sqlBuilder, entityId, event.LinkUpdated)
+}
+
+func (lp *LinkRepo) changeDeleted(ctx context.Context, sqlBuilder squirrel.UpdateBuilder, entityId uint64, eventType event.Type) error {
+	sql, params, err := sqlBuilder.
+		Suffix("RETURNING " + strings.Join(linkColumns, ", ")).
+		ToSql()
 	if err != nil {
 		return err
 	}
 
-	_, err = lp.db.Exec(sql, entityId)
+	return lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		result := &link.Link{}
+		found, err := getInserted(ctx, tx, result, sql, params...)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return notFound(entityId)
+		}
+		if err := loadTags(ctx, tx, result); err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, eventType, result)
+	})
+}
+
+// PurgeDeletedEntities removes links deleted before olderThan for good, each with a LinkPurged event.
+// Their tags are already gone from the payload, LinkDeleted carried them.
+func (lp *LinkRepo) PurgeDeletedEntities(ctx context.Context, olderThan time.Time) (_ uint64, err error) {
+	ctx, finish := lp.startOperation(ctx, "PurgeDeletedEntities", lp.timeouts.Batch)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Delete("links").
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		Where(squirrel.Lt{"deleted_at": olderThan}).
+		Suffix("RETURNING " + strings.Join(linkColumns, ", "))
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	purged := make([]link.Link, 0)
+	err = lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := selectRows(ctx, tx, &purged, sql, params...); err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, event.LinkPurged, linkPointers(purged)...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(purged)), nil
+}
+
+func (lp *LinkRepo) UpdateEntity(ctx context.Context, entity link.Link, fields []string) (_ *link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "UpdateEntity", lp.timeouts.Write)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("links")
+
+	updateTags := false
+	for _, field := range fields {
+		switch field {
+		case "url":
+			sqlBuilder = sqlBuilder.
+				Set("url", entity.Url).
+				Set("canonical_url", entity.CanonicalUrl).
+				Set("enriched_at", nil)
+		case "description":
+			sqlBuilder = sqlBuilder.Set("description", entity.Description)
+		case "tags":
+			updateTags = true
+		default:
+			return nil, invalidInput(field, errors.New("field cannot be updated"))
+		}
+	}
+
+	sqlBuilder = sqlBuilder.
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": entity.ID, "deleted_at": nil}).
+		Suffix("RETURNING " + strings.Join(linkColumns, ", "))
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &link.Link{}
+	err = lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := getRow(ctx, tx, result, sql, params...); err != nil {
+			return err
+		}
+		if updateTags {
+			result.Tags = entity.GetTagsAsSlice()
+			err = replaceTags(ctx, tx, result)
+		} else {
+			err = loadTags(ctx, tx, result)
+		}
+		if err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, event.LinkUpdated, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (lp *LinkRepo) ListEntitiesToEnrich(ctx context.Context, limit uint64) (_ []link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "ListEntitiesToEnrich", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(linkColumns...).
+		From("links").
+		Where(squirrel.Eq{"enriched_at": nil, "deleted_at": nil}).
+		OrderBy("id").
+		Limit(limit)
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]link.Link, 0, limit)
+	err = selectRows(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+// UpdateEntityMetadata saves metadata fetched from url. If the link has been given another url
+// or purged in the meantime the metadata is stale, it is dropped with an ErrNotFound error.
+func (lp *LinkRepo) UpdateEntityMetadata(ctx context.Context, entityId uint64, url string, metadata link.Metadata) (err error) {
+	ctx, finish := lp.startOperation(ctx, "UpdateEntityMetadata", lp.timeouts.Write)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("links").
+		Set("title", metadata.Title).
+		Set("page_description", metadata.PageDescription).
+		Set("image_url", metadata.ImageUrl).
+		Set("favicon_url", metadata.FaviconUrl).
+		Set("page_canonical_url", metadata.PageCanonicalUrl).
+		Set("enriched_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": entityId}).
+		Where(squirrel.Eq{"url": url})
+
+	sql, params, err := sqlBuilder.ToSql()
 	if err != nil {
 		return err
 	}
 
+	result, err := exec(ctx, lp.db, sql, params...)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+	if affected == 0 {
+		return &Error{Kind: ErrNotFound, Err: fmt.Errorf("link %d with url %q", entityId, url)}
+	}
+
 	return nil
 }
+
+// ListEntitiesToCheck returns links after afterID in order of id that have not been checked since checkedBefore.
+func (lp *LinkRepo) ListEntitiesToCheck(ctx context.Context, checkedBefore time.Time, afterID uint64, limit uint64) (_ []link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "ListEntitiesToCheck", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(linkColumns...).
+		From("links").
+		Where(squirrel.Eq{"deleted_at": nil}).
+		Where(squirrel.Gt{"id": afterID}).
+		Where("NOT EXISTS (SELECT 1 FROM link_checks WHERE link_checks.link_id = links.id AND link_checks.checked_at >= ?)", checkedBefore).
+		OrderBy("id").
+		Limit(limit)
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]link.Link, 0, limit)
+	err = selectRows(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+// AddChecks saves checks and drops the checks of their links beyond the keptChecks latest ones.
+func (lp *LinkRepo) AddChecks(ctx context.Context, checks []link.Check) (err error) {
+	ctx, finish := lp.startOperation(ctx, "AddChecks", lp.timeouts.Batch)
+	defer func() { err = finish(err) }()
+
+	if len(checks) == 0 {
+		return nil
+	}
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("link_checks").
+		Columns(checkColumns...)
+
+	ids := make([]uint64, 0, len(checks))
+	for _, check := range checks {
+		sqlBuilder = sqlBuilder.Values(check.LinkID, check.StatusCode, check.FinalUrl, check.Error, check.CheckedAt)
+		ids = append(ids, check.LinkID)
+	}
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	return lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := exec(ctx, tx, sql, params...); err != nil {
+			return err
+		}
+		return pruneChecks(ctx, tx, ids)
+	})
+}
+
+func (lp *LinkRepo) ListBrokenEntities(ctx context.Context, userID uint64, limit uint64, offset uint64) (_ []link.Link, err error) {
+	ctx, finish := lp.startOperation(ctx, "ListBrokenEntities", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	where := squirrel.And{
+		squirrel.Eq{"deleted_at": nil},
+		squirrel.Or{squirrel.NotEq{"c.error": ""}, squirrel.GtOrEq{"c.status_code": 400}},
+	}
+	if userID != 0 {
+		where = append(where, squirrel.Eq{"user_id": userID})
+	}
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(linkColumns...).
+		From("links").
+		JoinClause("JOIN "+lastCheck).
+		Where(where).
+		OrderBy("c.checked_at DESC", "id").
+		Limit(limit).Offset(offset)
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]link.Link, 0, limit)
+	err = selectRows(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	entities := linkPointers(result)
+	if err := loadTags(ctx, lp.db, entities...); err != nil {
+		return nil, wrapError(err)
+	}
+	if err := loadLastChecks(ctx, lp.db, entities...); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+func (lp *LinkRepo) SearchEntities(ctx context.Context, query SearchQuery, filter Filter, limit uint64, offset uint64) (_ []SearchResult, err error) {
+	ctx, finish := lp.startOperation(ctx, "SearchEntities", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(searchColumns(query)...).
+		From("links")
+
+	where := filter.where()
+	if !query.IsEmpty() {
+		tsQuery, params := query.tsQuery()
+		sqlBuilder = sqlBuilder.JoinClause("CROSS JOIN (SELECT "+tsQuery+" AS query) q", params...)
+		where = append(where, squirrel.Expr("search_vector @@ q.query"))
+	}
+
+	sql, params, err := sqlBuilder.
+		Where(where).
+		OrderBy("rank DESC", "id").
+		Limit(limit).Offset(offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]SearchResult, 0, limit)
+	err = selectRows(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	entities := make([]*link.Link, 0, len(result))
+	for i := range result {
+		entities = append(entities, &result[i].Link)
+	}
+	if err := loadTags(ctx, lp.db, entities...); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+func (lp *LinkRepo) ExistingCanonicalUrls(ctx context.Context, userID uint64, canonicalUrls []string) (_ []string, err error) {
+	ctx, finish := lp.startOperation(ctx, "ExistingCanonicalUrls", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	if len(canonicalUrls) == 0 {
+		return nil, nil
+	}
+
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select("canonical_url").
+		From("links").
+		Where(squirrel.Eq{"deleted_at": nil, "user_id": userID, "canonical_url": canonicalUrls}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(canonicalUrls))
+	if err := selectRows(ctx, lp.db, &result, sql, params...); err != nil {
+		return nil, wrapError(err)
+	}
+	return result, nil
+}
+
+func (lp *LinkRepo) ListTags(ctx context.Context, userID uint64) (_ []TagCount, err error) {
+	ctx, finish := lp.startOperation(ctx, "ListTags", lp.timeouts.Read)
+	defer func() { err = finish(err) }()
+
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select("t.name", "count(*) AS count").
+		From("tags t").
+		Join("link_tags lt ON lt.tag_id = t.id").
+		Join("links l ON l.id = lt.link_id").
+		Where(squirrel.Eq{"l.deleted_at": nil}).
+		GroupBy("t.name").
+		OrderBy("t.name")
+
+	if userID != 0 {
+		sqlBuilder = sqlBuilder.Where(squirrel.Eq{"l.user_id": userID})
+	}
+
+	sql, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TagCount, 0)
+	err = selectRows(ctx, lp.db, &result, sql, params...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return result, nil
+}
+
+func (lp *LinkRepo) RenameTag(ctx context.Context, userID uint64, name string, newName string) (_ uint64, err error) {
+	ctx, finish := lp.startOperation(ctx, "RenameTag", lp.timeouts.Batch)
+	defer func() { err = finish(err) }()
+
+	var updated uint64
+	err = lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := lockTagName(ctx, tx, userID, newName); err != nil {
+			return err
+		}
+		used, err := countTagged(ctx, tx, userID, newName)
+		if err != nil {
+			return err
+		}
+		if used > 0 {
+			return &Error{Kind: ErrConflict, Field: "new_name", Err: fmt.Errorf("tag %q is already in use", newName)}
+		}
+
+		entities, err := lockTagged(ctx, tx, userID, []string{name})
+		if err != nil {
+			return err
+		}
+		if len(entities) == 0 {
+			return tagNotFound(name)
+		}
+
+		updated = uint64(len(entities))
+		if err := retag(ctx, tx, entities, []string{name}, newName); err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, event.LinkUpdated, entities...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+func (lp *LinkRepo) MergeTags(ctx context.Context, userID uint64, names []string, target string) (_ uint64, err error) {
+	ctx, finish := lp.startOperation(ctx, "MergeTags", lp.timeouts.Batch)
+	defer func() { err = finish(err) }()
+
+	var updated uint64
+	err = lp.withTx(ctx, func(tx *sqlx.Tx) error {
+		entities, err := lockTagged(ctx, tx, userID, names)
+		if err != nil {
+			return err
+		}
+		if len(entities) == 0 {
+			return tagNotFound(names...)
+		}
+
+		updated = uint64(len(entities))
+		if err := retag(ctx, tx, entities, names, target); err != nil {
+			return err
+		}
+		return addEvents(ctx, tx, event.LinkUpdated, entities...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// startOperation traces a repo call and applies its timeout. The returned finish ends both and
+// explains an interrupted query: drivers report it in their own words, the context knows why.
+func (lp *LinkRepo) startOperation(ctx context.Context, method string, timeout time.Duration) (context.Context, func(err error) error) {
+	parent := ctx
+	ctx, span := startSpan(ctx, method)
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	return ctx, func(err error) error {
+		switch {
+		case err == nil:
+		case parent.Err() != nil:
+			err = parent.Err()
+		case ctx.Err() != nil:
+			err = ctx.Err()
+		}
+		cancel()
+		finishSpan(span, err)
+		return err
+	}
+}
+
+func (lp *LinkRepo) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := lp.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return wrapError(err)
+	}
+
+	_, span := startQuerySpan(ctx, "COMMIT")
+	err = tx.Commit()
+	finishSpan(span, err)
+	return wrapError(err)
+}
+
+type duplicateKey struct {
+	userID       uint64
+	canonicalUrl string
+}
+
+func insertLink(entity link.Link) squirrel.InsertBuilder {
+	return squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("links").
+		Columns("user_id", "url", "canonical_url", "description").
+		Values(entity.UserID, entity.Url, entity.CanonicalUrl, entity.Description).
+		Suffix(onDuplicateUrl + " RETURNING " + strings.Join(linkColumns, ", "))
+}
+
+// createdAt keeps the original creation time of imported links and lets the database set it otherwise.
+func createdAt(entity link.Link) interface{} {
+	if entity.CreatedAt.IsZero() {
+		return squirrel.Expr("DEFAULT")
+	}
+	return entity.CreatedAt
+}
+
+func getInserted(ctx context.Context, tx *sqlx.Tx, result *link.Link, query string, params ...interface{}) (bool, error) {
+	err := getRow(ctx, tx, result, query, params...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func findDuplicate(ctx context.Context, tx *sqlx.Tx, entity link.Link, forUpdate bool) (*link.Link, error) {
+	sqlBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select(linkColumns...).
+		From("links").
+		Where(squirrel.Eq{"user_id": entity.UserID, "canonical_url": entity.CanonicalUrl, "deleted_at": nil})
+	if forUpdate {
+		sqlBuilder = sqlBuilder.Suffix("FOR UPDATE")
+	}
+
+	query, params, err := sqlBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &link.Link{}
+	if err := getRow(ctx, tx, result, query, params...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func linkPointers(entities []link.Link) []*link.Link {
+	result := make([]*link.Link, 0, len(entities))
+	for i := range entities {
+		result = append(result, &entities[i])
+	}
+	return result
+}
@@ -1,201 +1,1390 @@
 package repo_test
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"net"
 	"time"
 
+	"github.com/ozonva/ova-link-api/internal/event"
 	"github.com/ozonva/ova-link-api/internal/link"
 
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 
+	"github.com/jackc/pgx"
 	"github.com/jmoiron/sqlx"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/ozonva/ova-link-api/internal/repo"
 )
 
+var linkColumns = []string{
+	"id", "user_id", "url", "canonical_url", "description", "created_at", "updated_at", "deleted_at",
+	"title", "page_description", "image_url", "favicon_url", "page_canonical_url", "enriched_at",
+}
+
+const linkColumnList = "id, user_id, url, canonical_url, description, created_at, updated_at, deleted_at, " +
+	"title, page_description, image_url, favicon_url, page_canonical_url, enriched_at"
+
+const insertOutbox = "INSERT INTO outbox \\(link_id,event_type,payload\\) VALUES "
+
+const selectTags = "SELECT lt.link_id, t.name FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id IN "
+
+// payloadArg matches an outbox payload by the decoded link.
+type payloadArg func(payload event.LinkPayload) bool
+
+func (match payloadArg) Match(value driver.Value) bool {
+	raw, ok := value.(string)
+	if !ok {
+		return false
+	}
+	var payload event.LinkPayload
+	return json.Unmarshal([]byte(raw), &payload) == nil && match(payload)
+}
+
 var _ = Describe("Repo", func() {
 	Context("Link", func() {
 		var linkRepo repo.Repo
 		var db *sqlx.DB
 		var dbMock sqlxmock.Sqlmock
 		var err error
+		var ctx context.Context
 
 		BeforeEach(func() {
+			ctx = context.Background()
 			db, dbMock, err = sqlxmock.Newx()
 			if err != nil {
 				log.Fatalln("cannot create db mock")
 			}
-			linkRepo = repo.NewLinkRepo(db)
+			linkRepo = repo.NewLinkRepo(db, repo.Timeouts{})
 		})
 
 		It("Describe success", func() {
 			selectTime := time.Now()
-			dbMock.ExpectQuery("SELECT id, user_id, url, description, tags, created_at FROM links WHERE id = \\$1").
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE id = \\$1 AND deleted_at IS NULL").
 				WithArgs(1).
 				WillReturnRows(
 					sqlxmock.
-						NewRows([]string{"id", "user_id", "url", "description", "tags", "created_at"}).
-						AddRow(1, 1, "https://test.com", "test description", "tag1#tag2", selectTime),
+						NewRows(linkColumns).
+						AddRow(1, 1, "https://test.com", "https://test.com", "test description", selectTime, selectTime, nil, "", "", "", "", "", nil),
 				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(1, "tag1").AddRow(1, "tag2"))
+			dbMock.ExpectQuery("SELECT DISTINCT ON \\(link_id\\) link_id, status_code, final_url, error, checked_at FROM link_checks " +
+				"WHERE link_id IN \\(\\$1\\) ORDER BY link_id, checked_at DESC").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "status_code", "final_url", "error", "checked_at"}).
+					AddRow(1, 200, "https://test.com/", "", selectTime))
 
-			result, err := linkRepo.DescribeEntity(1)
+			result, err := linkRepo.DescribeEntity(ctx, 1)
 
 			Expect(result).Should(BeEquivalentTo(&link.Link{
-				ID:          1,
-				UserID:      1,
-				Url:         "https://test.com",
-				Description: "test description",
-				Tags:        "tag1#tag2",
-				CreatedAt:   selectTime,
+				ID:           1,
+				UserID:       1,
+				Url:          "https://test.com",
+				CanonicalUrl: "https://test.com",
+				Description:  "test description",
+				Tags:         []string{"tag1", "tag2"},
+				CreatedAt:    selectTime,
+				UpdatedAt:    selectTime,
+				LastCheck: &link.Check{
+					LinkID:     1,
+					StatusCode: 200,
+					FinalUrl:   "https://test.com/",
+					CheckedAt:  selectTime,
+				},
 			}))
 			Expect(err).Should(Succeed())
 		})
 
 		It("Describe error", func() {
-			dbMock.ExpectQuery("SELECT id, user_id, url, description, tags, created_at FROM links WHERE id = \\$1").
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE id = \\$1 AND deleted_at IS NULL").
 				WithArgs(1).
 				WillReturnError(errors.New("not found"))
 
-			result, err := linkRepo.DescribeEntity(1)
+			result, err := linkRepo.DescribeEntity(ctx, 1)
 
 			Expect(result).Should(BeNil())
 			Expect(err).Should(HaveOccurred())
 		})
 
+		It("Describe not found", func() {
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE id = \\$1 AND deleted_at IS NULL").
+				WithArgs(1).
+				WillReturnError(sql.ErrNoRows)
+
+			_, err := linkRepo.DescribeEntity(ctx, 1)
+
+			Expect(errors.Is(err, repo.ErrNotFound)).Should(BeTrue())
+		})
+
+		It("Describe unavailable", func() {
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE id = \\$1 AND deleted_at IS NULL").
+				WithArgs(1).
+				WillReturnError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+			_, err := linkRepo.DescribeEntity(ctx, 1)
+
+			Expect(errors.Is(err, repo.ErrUnavailable)).Should(BeTrue())
+		})
+
+		It("Describe timed out in the driver. Should report the deadline, not an unavailable storage.", func() {
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE id = \\$1 AND deleted_at IS NULL").
+				WithArgs(1).
+				WillReturnError(fmt.Errorf("read: %w", context.DeadlineExceeded))
+
+			_, err := linkRepo.DescribeEntity(ctx, 1)
+
+			Expect(errors.Is(err, context.DeadlineExceeded)).Should(BeTrue())
+			Expect(errors.Is(err, repo.ErrUnavailable)).Should(BeFalse())
+		})
+
 		It("List success", func() {
 			selectTime1 := time.Now()
 			selectTime2 := time.Now()
 			selectTime3 := time.Now()
-			dbMock.ExpectQuery("SELECT id, user_id, url, description, tags, created_at FROM links LIMIT 2 OFFSET 2").
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE \\(deleted_at IS NULL\\) ORDER BY id LIMIT 2 OFFSET 2").
 				WillReturnRows(
 					sqlxmock.
-						NewRows([]string{"id", "user_id", "url", "description", "tags", "created_at"}).
-						AddRow(3, 1, "https://test.com3", "test description3", "tag3#tag6", selectTime1).
-						AddRow(4, 1, "https://test.com4", "test description4", "tag4#tag7", selectTime2).
-						AddRow(5, 3, "https://test.com5", "test description5", "tag5#tag8", selectTime3),
+						NewRows(linkColumns).
+						AddRow(3, 1, "https://test.com3", "https://test.com3", "test description3", selectTime1, selectTime1, nil, "", "", "", "", "", nil).
+						AddRow(4, 1, "https://test.com4", "https://test.com4", "test description4", selectTime2, selectTime2, nil, "", "", "", "", "", nil).
+						AddRow(5, 3, "https://test.com5", "https://test.com5", "test description5", selectTime3, selectTime3, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags+"\\(\\$1,\\$2,\\$3\\) ORDER BY lt.link_id, t.name").
+				WithArgs(3, 4, 5).
+				WillReturnRows(
+					sqlxmock.NewRows([]string{"link_id", "name"}).
+						AddRow(3, "tag3").AddRow(3, "tag6").
+						AddRow(4, "tag4").AddRow(4, "tag7").
+						AddRow(5, "tag5").AddRow(5, "tag8"),
 				)
 
 			expected := []link.Link{
 				{
-					ID:          3,
-					UserID:      1,
-					Url:         "https://test.com3",
-					Description: "test description3",
-					Tags:        "tag3#tag6",
-					CreatedAt:   selectTime1,
+					ID:           3,
+					UserID:       1,
+					Url:          "https://test.com3",
+					CanonicalUrl: "https://test.com3",
+					Description:  "test description3",
+					Tags:         []string{"tag3", "tag6"},
+					CreatedAt:    selectTime1,
+					UpdatedAt:    selectTime1,
 				},
 				{
-					ID:          4,
-					UserID:      1,
-					Url:         "https://test.com4",
-					Description: "test description4",
-					Tags:        "tag4#tag7",
-					CreatedAt:   selectTime2,
+					ID:           4,
+					UserID:       1,
+					Url:          "https://test.com4",
+					CanonicalUrl: "https://test.com4",
+					Description:  "test description4",
+					Tags:         []string{"tag4", "tag7"},
+					CreatedAt:    selectTime2,
+					UpdatedAt:    selectTime2,
 				},
 				{
-					ID:          5,
-					UserID:      3,
-					Url:         "https://test.com5",
-					Description: "test description5",
-					Tags:        "tag5#tag8",
-					CreatedAt:   selectTime3,
+					ID:           5,
+					UserID:       3,
+					Url:          "https://test.com5",
+					CanonicalUrl: "https://test.com5",
+					Description:  "test description5",
+					Tags:         []string{"tag5", "tag8"},
+					CreatedAt:    selectTime3,
+					UpdatedAt:    selectTime3,
 				},
 			}
-			result, err := linkRepo.ListEntities(2, 2)
+			result, err := linkRepo.ListEntities(ctx, repo.Filter{}, 2, 2)
 
 			Expect(result).Should(BeEquivalentTo(expected))
 			Expect(err).Should(Succeed())
 		})
 
+		It("List with filter success", func() {
+			selectTime := time.Now()
+			createdFrom := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+			createdTo := time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links "+
+				"WHERE \\(deleted_at IS NULL AND user_id = \\$1 "+
+				"AND EXISTS \\(SELECT 1 FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = links.id AND t.name = \\$2\\) "+
+				"AND EXISTS \\(SELECT 1 FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = links.id AND t.name = \\$3\\) "+
+				"AND \\(url ILIKE \\$4 OR description ILIKE \\$5\\) "+
+				"AND created_at >= \\$6 AND created_at < \\$7\\) ORDER BY id LIMIT 10 OFFSET 0").
+				WithArgs(1, "tag1", "tag_2", "%50\\%%", "%50\\%%", createdFrom, createdTo).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(3, 1, "https://test.com3", "https://test.com3", "50% off", selectTime, selectTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(3).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(3, "tag1").AddRow(3, "tag_2"))
+
+			result, err := linkRepo.ListEntities(ctx, repo.Filter{
+				UserID:       1,
+				Tags:         []string{"tag1", "tag_2"},
+				MatchAllTags: true,
+				Search:       "50%",
+				CreatedFrom:  createdFrom,
+				CreatedTo:    createdTo,
+			}, 10, 0)
+
+			Expect(result).Should(HaveLen(1))
+			Expect(result[0].Tags).Should(Equal([]string{"tag1", "tag_2"}))
+			Expect(err).Should(Succeed())
+		})
+
+		It("List with any of tags", func() {
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links "+
+				"WHERE \\(deleted_at IS NULL AND EXISTS \\(SELECT 1 FROM link_tags lt JOIN tags t ON t.id = lt.tag_id "+
+				"WHERE lt.link_id = links.id AND t.name IN \\(\\$1,\\$2\\)\\)\\) "+
+				"ORDER BY id LIMIT 10 OFFSET 0").
+				WithArgs("tag1", "tag2").
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+
+			result, err := linkRepo.ListEntities(ctx, repo.Filter{Tags: []string{"tag1", "tag2"}}, 10, 0)
+
+			Expect(result).Should(BeEmpty())
+			Expect(err).Should(Succeed())
+		})
+
+		It("List after cursor success", func() {
+			selectTime := time.Now()
+			cursorTime := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links "+
+				"WHERE \\(deleted_at IS NULL AND user_id = \\$1 AND \\(created_at, id\\) > \\(\\$2, \\$3\\)\\) "+
+				"ORDER BY created_at, id LIMIT 3").
+				WithArgs(1, cursorTime, 5).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(6, 1, "https://test.com6", "https://test.com6", "test description6", selectTime, selectTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(6).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}))
+
+			result, err := linkRepo.ListEntitiesAfter(ctx, repo.Filter{UserID: 1}, &repo.Cursor{CreatedAt: cursorTime, ID: 5}, 3)
+
+			Expect(result).Should(HaveLen(1))
+			Expect(result[0].ID).Should(Equal(uint64(6)))
+			Expect(err).Should(Succeed())
+		})
+
+		It("List first page success", func() {
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links " +
+				"WHERE \\(deleted_at IS NULL\\) ORDER BY created_at, id LIMIT 3").
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+
+			result, err := linkRepo.ListEntitiesAfter(ctx, repo.Filter{}, nil, 3)
+
+			Expect(result).Should(BeEmpty())
+			Expect(err).Should(Succeed())
+		})
+
+		It("Count success", func() {
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM links WHERE \\(deleted_at IS NULL AND user_id = \\$1\\)").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"count"}).AddRow(42))
+
+			result, err := linkRepo.CountEntities(ctx, repo.Filter{UserID: 1})
+
+			Expect(result).Should(Equal(uint64(42)))
+			Expect(err).Should(Succeed())
+		})
+
+		It("Count error", func() {
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM links").
+				WillReturnError(errors.New("something goes wrong"))
+
+			_, err := linkRepo.CountEntities(ctx, repo.Filter{})
+
+			Expect(err).Should(HaveOccurred())
+		})
+
 		It("List error", func() {
-			dbMock.ExpectQuery("SELECT id, user_id, url, description, tags, created_at FROM links LIMIT 2 OFFSET 2").
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE \\(deleted_at IS NULL\\) ORDER BY id LIMIT 2 OFFSET 2").
 				WillReturnError(errors.New("something goes wrong"))
 
-			result, err := linkRepo.ListEntities(2, 2)
+			result, err := linkRepo.ListEntities(ctx, repo.Filter{}, 2, 2)
 
 			Expect(result).Should(BeNil())
 			Expect(err).Should(HaveOccurred())
 		})
 
 		It("Delete success", func() {
-			dbMock.ExpectExec("DELETE FROM links WHERE id = \\$1").
+			deleteTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET deleted_at = now\\(\\) WHERE deleted_at IS NULL AND id = \\$1 RETURNING " + linkColumnList).
 				WithArgs(1).
-				WillReturnResult(sqlxmock.NewResult(1, 1))
+				WillReturnRows(sqlxmock.NewRows(linkColumns).
+					AddRow(1, 1, "https://test.com", "https://test.com", "", deleteTime, deleteTime, deleteTime, "", "", "", "", "", nil))
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(1, "tag1"))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(1, "LinkDeleted", payloadArg(func(payload event.LinkPayload) bool {
+					return payload.ID == 1 && payload.DeletedAt != nil && payload.Tags[0] == "tag1"
+				})).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
 
-			err := linkRepo.DeleteEntity(1)
+			err := linkRepo.DeleteEntity(ctx, 1)
 
 			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
 		})
 
 		It("Delete error", func() {
-			dbMock.ExpectExec("DELETE FROM links WHERE id = \\$1").
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET deleted_at = now\\(\\) WHERE deleted_at IS NULL AND id = \\$1").
+				WithArgs(1).
+				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
+
+			err := linkRepo.DeleteEntity(ctx, 1)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Delete not found", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET deleted_at = now\\(\\) WHERE deleted_at IS NULL AND id = \\$1").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+			dbMock.ExpectRollback()
+
+			err := linkRepo.DeleteEntity(ctx, 1)
+
+			Expect(errors.Is(err, repo.ErrNotFound)).Should(BeTrue())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("List deleted success", func() {
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links " +
+				"WHERE \\(deleted_at IS NOT NULL AND user_id = \\$1\\) ORDER BY id LIMIT 2 OFFSET 0").
 				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+
+			result, err := linkRepo.ListEntities(ctx, repo.Filter{UserID: 1, OnlyDeleted: true}, 2, 0)
+
+			Expect(result).Should(BeEmpty())
+			Expect(err).Should(Succeed())
+		})
+
+		It("Restore success", func() {
+			restoreTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET deleted_at = \\$1 WHERE id = \\$2 AND deleted_at IS NOT NULL RETURNING "+linkColumnList).
+				WithArgs(nil, 1).
+				WillReturnRows(sqlxmock.NewRows(linkColumns).
+					AddRow(1, 1, "https://test.com", "https://test.com", "", restoreTime, restoreTime, nil, "", "", "", "", "", nil))
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(1, "LinkUpdated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			err := linkRepo.RestoreEntity(ctx, 1)
+
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Restore error", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET deleted_at = \\$1 WHERE id = \\$2 AND deleted_at IS NOT NULL").
+				WithArgs(nil, 1).
 				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
+
+			err := linkRepo.RestoreEntity(ctx, 1)
 
-			err := linkRepo.DeleteEntity(1)
 			Expect(err).Should(HaveOccurred())
 		})
 
+		It("Purge success", func() {
+			olderThan := time.Now()
+			deleteTime := olderThan.Add(-time.Hour)
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("DELETE FROM links WHERE deleted_at IS NOT NULL AND deleted_at < \\$1 RETURNING " + linkColumnList).
+				WithArgs(olderThan).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(1, 1, "https://test.com", "https://test.com", "", deleteTime, deleteTime, deleteTime, "", "", "", "", "", nil).
+						AddRow(2, 1, "https://test2.com", "https://test2.com", "", deleteTime, deleteTime, deleteTime, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\),\\(\\$4,\\$5,\\$6\\)").
+				WithArgs(
+					1, "LinkPurged", payloadArg(func(payload event.LinkPayload) bool { return payload.ID == 1 && payload.DeletedAt != nil }),
+					2, "LinkPurged", payloadArg(func(payload event.LinkPayload) bool { return payload.ID == 2 && payload.DeletedAt != nil }),
+				).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectCommit()
+
+			purged, err := linkRepo.PurgeDeletedEntities(ctx, olderThan)
+
+			Expect(purged).Should(Equal(uint64(2)))
+			Expect(err).Should(Succeed())
+		})
+
+		It("Purge nothing", func() {
+			olderThan := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("DELETE FROM links WHERE deleted_at IS NOT NULL AND deleted_at < \\$1").
+				WithArgs(olderThan).
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+			dbMock.ExpectCommit()
+
+			purged, err := linkRepo.PurgeDeletedEntities(ctx, olderThan)
+
+			Expect(purged).Should(BeZero())
+			Expect(err).Should(Succeed())
+		})
+
+		It("Purge error", func() {
+			olderThan := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("DELETE FROM links WHERE deleted_at IS NOT NULL AND deleted_at < \\$1").
+				WithArgs(olderThan).
+				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.PurgeDeletedEntities(ctx, olderThan)
+
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Create one success", func() {
+			createTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description\\) VALUES \\(\\$1,\\$2,\\$3,\\$4\\) "+
+				"ON CONFLICT \\(user_id, canonical_url\\) WHERE deleted_at IS NULL DO NOTHING "+
+				"RETURNING "+linkColumnList).
+				WithArgs(1, "https://test.com", "https://test.com", "test description").
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(7, 1, "https://test.com", "https://test.com", "test description", createTime, createTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\),\\(\\$2\\) "+
+				"ON CONFLICT \\(name\\) DO UPDATE SET name = EXCLUDED.name RETURNING id, name").
+				WithArgs("tag1", "tag2").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "name"}).AddRow(11, "tag1").AddRow(12, "tag2"))
+			dbMock.ExpectExec("INSERT INTO link_tags \\(link_id,tag_id\\) VALUES \\(\\$1,\\$2\\),\\(\\$3,\\$4\\) ON CONFLICT DO NOTHING").
+				WithArgs(7, 12, 7, 11).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(7, "LinkCreated", payloadArg(func(payload event.LinkPayload) bool {
+					return payload.ID == 7 && payload.Url == "https://test.com" && len(payload.Tags) == 2 && payload.DeletedAt == nil
+				})).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			entity := link.New(1, "https://test.com")
+			entity.Description = "test description"
+			entity.SetTagsAsSlice([]string{"tag2", "tag1"})
+			result, err := linkRepo.AddEntity(ctx, *entity)
+
+			Expect(result).Should(BeEquivalentTo(&link.Link{
+				ID:           7,
+				UserID:       1,
+				Url:          "https://test.com",
+				CanonicalUrl: "https://test.com",
+				Description:  "test description",
+				Tags:         []string{"tag2", "tag1"},
+				CreatedAt:    createTime,
+				UpdatedAt:    createTime,
+			}))
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Create one without tags", func() {
+			createTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description\\) VALUES \\(\\$1,\\$2,\\$3,\\$4\\)").
+				WithArgs(1, "https://test.com", "https://test.com", "").
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(7, 1, "https://test.com", "https://test.com", "", createTime, createTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(7, "LinkCreated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			result, err := linkRepo.AddEntity(ctx, *link.New(1, "https://test.com"))
+
+			Expect(result.ID).Should(Equal(uint64(7)))
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Create one error", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description\\) VALUES \\(\\$1,\\$2,\\$3,\\$4\\)").
+				WithArgs(1, "https://test.com", "https://test.com", "").
+				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
+
+			result, err := linkRepo.AddEntity(ctx, *link.New(1, "https://test.com"))
+
+			Expect(result).Should(BeNil())
+			Expect(err).Should(HaveOccurred())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Create one conflict", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description\\) VALUES \\(\\$1,\\$2,\\$3,\\$4\\)").
+				WithArgs(1, "https://test.com", "https://test.com", "").
+				WillReturnError(pgx.PgError{Code: "23505", ConstraintName: "links_pkey"})
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.AddEntity(ctx, *link.New(1, "https://test.com"))
+
+			Expect(errors.Is(err, repo.ErrConflict)).Should(BeTrue())
+		})
+
+		It("Create one invalid input", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description\\) VALUES \\(\\$1,\\$2,\\$3,\\$4\\)").
+				WithArgs(1, "https://test.com", "https://test.com", "").
+				WillReturnError(pgx.PgError{Code: "23502", ColumnName: "url"})
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.AddEntity(ctx, *link.New(1, "https://test.com"))
+
+			var repoErr *repo.Error
+			Expect(errors.As(err, &repoErr)).Should(BeTrue())
+			Expect(repoErr.Kind).Should(Equal(repo.ErrInvalidInput))
+			Expect(repoErr.Field).Should(Equal("url"))
+		})
+
+		It("Create one duplicate", func() {
+			createTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links").
+				WithArgs(1, "http://Test.com/", "https://test.com", "").
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links "+
+				"WHERE canonical_url = \\$1 AND deleted_at IS NULL AND user_id = \\$2$").
+				WithArgs("https://test.com", 1).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(5, 1, "https://test.com", "https://test.com", "", createTime, createTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.AddEntity(ctx, *link.New(1, "http://Test.com/"))
+
+			var repoErr *repo.Error
+			Expect(errors.As(err, &repoErr)).Should(BeTrue())
+			Expect(repoErr.Kind).Should(Equal(repo.ErrConflict))
+			Expect(repoErr.ID).Should(Equal(uint64(5)))
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Upsert merges tags into duplicate", func() {
+			createTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links").
+				WithArgs(1, "https://test.com/?utm_source=mail", "https://test.com", "").
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links "+
+				"WHERE canonical_url = \\$1 AND deleted_at IS NULL AND user_id = \\$2 FOR UPDATE").
+				WithArgs("https://test.com", 1).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(5, 1, "https://test.com", "https://test.com", "", createTime, createTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(5).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(5, "tag1"))
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\),\\(\\$2\\)").
+				WithArgs("tag1", "tag2").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "name"}).AddRow(11, "tag1").AddRow(12, "tag2"))
+			dbMock.ExpectExec("INSERT INTO link_tags \\(link_id,tag_id\\) VALUES \\(\\$1,\\$2\\),\\(\\$3,\\$4\\) ON CONFLICT DO NOTHING").
+				WithArgs(5, 11, 5, 12).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(5, "LinkUpdated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			entity := link.New(1, "https://test.com/?utm_source=mail")
+			entity.SetTagsAsSlice([]string{"tag2"})
+			result, err := linkRepo.UpsertEntity(ctx, *entity)
+
+			Expect(err).Should(Succeed())
+			Expect(result.ID).Should(Equal(uint64(5)))
+			Expect(result.Url).Should(Equal("https://test.com"))
+			Expect(result.Tags).Should(Equal([]string{"tag1", "tag2"}))
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Upsert without new tags changes nothing", func() {
+			createTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links").
+				WithArgs(1, "https://test.com", "https://test.com", "").
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links").
+				WithArgs("https://test.com", 1).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(5, 1, "https://test.com", "https://test.com", "", createTime, createTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(5).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(5, "tag1"))
+			dbMock.ExpectCommit()
+
+			entity := link.New(1, "https://test.com")
+			entity.SetTagsAsSlice([]string{"tag1"})
+			result, err := linkRepo.UpsertEntity(ctx, *entity)
+
+			Expect(err).Should(Succeed())
+			Expect(result.ID).Should(Equal(uint64(5)))
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Create skips duplicates", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links").
+				WithArgs(1, "https://test.com", "https://test.com", "", sqlxmock.AnyArg(), 1, "https://test.com/", "https://test.com", "", sqlxmock.AnyArg()).
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "user_id", "canonical_url"}).AddRow(3, 1, "https://test.com"))
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\)").
+				WithArgs("tag1").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "name"}).AddRow(11, "tag1"))
+			dbMock.ExpectExec("INSERT INTO link_tags \\(link_id,tag_id\\) VALUES \\(\\$1,\\$2\\) ON CONFLICT DO NOTHING").
+				WithArgs(3, 11).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(3, "LinkCreated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			first := link.New(1, "https://test.com")
+			first.SetTagsAsSlice([]string{"tag1"})
+			second := link.New(1, "https://test.com/")
+			second.SetTagsAsSlice([]string{"tag2"})
+			err := linkRepo.AddEntities(ctx, []link.Link{*first, *second})
+
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
 		It("Create success", func() {
-			selectTime1 := time.Now()
-			selectTime2 := time.Now()
 			insert := []link.Link{
 				{
-					ID:          3,
-					UserID:      1,
-					Url:         "https://test.com3",
-					Description: "test description3",
-					Tags:        "tag3#tag6",
-					CreatedAt:   selectTime1,
+					UserID:       1,
+					Url:          "https://test.com3",
+					CanonicalUrl: "https://test.com3",
+					Description:  "test description3",
+					Tags:         []string{"tag3", "tag6"},
 				},
 				{
-					ID:          4,
-					UserID:      1,
-					Url:         "https://test.com4",
-					Description: "test description4",
-					Tags:        "tag4#tag7",
-					CreatedAt:   selectTime2,
+					UserID:       1,
+					Url:          "https://test.com4",
+					CanonicalUrl: "https://test.com4",
+					Description:  "test description4",
+					Tags:         []string{"tag6"},
 				},
 			}
 
-			dbMock.ExpectExec("INSERT INTO links \\(user_id,url,description,tags\\) VALUES \\(\\$1,\\$2,\\$3,\\$4\\),\\(\\$5,\\$6,\\$7,\\$8\\)").
-				WithArgs(1, "https://test.com3", "test description3", "tag3#tag6", 1, "https://test.com4", "test description4", "tag4#tag7").
-				WillReturnResult(sqlxmock.NewResult(2, 2))
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description,created_at\\) VALUES \\(\\$1,\\$2,\\$3,\\$4,DEFAULT\\),\\(\\$5,\\$6,\\$7,\\$8,DEFAULT\\) "+
+				"ON CONFLICT \\(user_id, canonical_url\\) WHERE deleted_at IS NULL DO NOTHING RETURNING id, user_id, canonical_url").
+				WithArgs(1, "https://test.com3", "https://test.com3", "test description3", 1, "https://test.com4", "https://test.com4", "test description4").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "user_id", "canonical_url"}).
+					AddRow(3, 1, "https://test.com3").
+					AddRow(4, 1, "https://test.com4"))
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\),\\(\\$2\\)").
+				WithArgs("tag3", "tag6").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "name"}).AddRow(13, "tag3").AddRow(16, "tag6"))
+			dbMock.ExpectExec("INSERT INTO link_tags \\(link_id,tag_id\\) VALUES \\(\\$1,\\$2\\),\\(\\$3,\\$4\\),\\(\\$5,\\$6\\)").
+				WithArgs(3, 13, 3, 16, 4, 16).
+				WillReturnResult(sqlxmock.NewResult(0, 3))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\),\\(\\$4,\\$5,\\$6\\)").
+				WithArgs(3, "LinkCreated", sqlxmock.AnyArg(), 4, "LinkCreated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectCommit()
 
-			err := linkRepo.AddEntities(insert)
+			err := linkRepo.AddEntities(ctx, insert)
 			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
 		})
 
 		It("Create error", func() {
-			selectTime1 := time.Now()
-			selectTime2 := time.Now()
 			insert := []link.Link{
 				{
-					ID:          3,
-					UserID:      1,
-					Url:         "https://test.com3",
-					Description: "test description3",
-					Tags:        "tag3#tag6",
-					CreatedAt:   selectTime1,
+					UserID:       1,
+					Url:          "https://test.com3",
+					CanonicalUrl: "https://test.com3",
+					Description:  "test description3",
+					Tags:         []string{"tag3", "tag6"},
 				},
 				{
-					ID:          4,
-					UserID:      1,
-					Url:         "https://test.com4",
-					Description: "test description4",
-					Tags:        "tag4#tag7",
-					CreatedAt:   selectTime2,
+					UserID:       1,
+					Url:          "https://test.com4",
+					CanonicalUrl: "https://test.com4",
+					Description:  "test description4",
+					Tags:         []string{"tag4", "tag7"},
 				},
 			}
 
-			dbMock.ExpectExec("INSERT INTO links \\(user_id,url,description,tags\\) VALUES \\(\\$1,\\$2,\\$3,\\$4\\),\\(\\$5,\\$6,\\$7,\\$8\\)").
-				WithArgs(1, "https://test.com3", "test description3", "tag3#tag6", 1, "https://test.com4", "test description4", "tag4#tag7").
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description,created_at\\) VALUES \\(\\$1,\\$2,\\$3,\\$4,DEFAULT\\),\\(\\$5,\\$6,\\$7,\\$8,DEFAULT\\) "+
+				"ON CONFLICT \\(user_id, canonical_url\\) WHERE deleted_at IS NULL DO NOTHING RETURNING id, user_id, canonical_url").
+				WithArgs(1, "https://test.com3", "https://test.com3", "test description3", 1, "https://test.com4", "https://test.com4", "test description4").
 				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
 
-			err := linkRepo.AddEntities(insert)
+			err := linkRepo.AddEntities(ctx, insert)
 			Expect(err).Should(HaveOccurred())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Create tags error rolls back links", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description,created_at\\) VALUES \\(\\$1,\\$2,\\$3,\\$4,DEFAULT\\)").
+				WithArgs(1, "https://test.com", "https://test.com", "").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "user_id", "canonical_url"}).AddRow(3, 1, "https://test.com"))
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\)").
+				WithArgs("tag").
+				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
+
+			err := linkRepo.AddEntities(ctx, []link.Link{{UserID: 1, Url: "https://test.com", CanonicalUrl: "https://test.com", Tags: []string{"tag"}}})
+			Expect(err).Should(HaveOccurred())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Create keeps creation time", func() {
+			createTime := time.Date(2015, 3, 1, 12, 0, 0, 0, time.UTC)
+
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links \\(user_id,url,canonical_url,description,created_at\\) VALUES \\(\\$1,\\$2,\\$3,\\$4,\\$5\\)").
+				WithArgs(1, "https://test.com", "https://test.com", "", createTime).
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "user_id", "canonical_url"}).AddRow(3, 1, "https://test.com"))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(3, "LinkCreated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			err := linkRepo.AddEntities(ctx, []link.Link{{UserID: 1, Url: "https://test.com", CanonicalUrl: "https://test.com", CreatedAt: createTime}})
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Existing canonical urls", func() {
+			dbMock.ExpectQuery("SELECT canonical_url FROM links WHERE canonical_url IN \\(\\$1,\\$2\\) AND deleted_at IS NULL AND user_id = \\$3").
+				WithArgs("https://test.com", "https://test2.com", 1).
+				WillReturnRows(sqlxmock.NewRows([]string{"canonical_url"}).AddRow("https://test2.com"))
+
+			result, err := linkRepo.ExistingCanonicalUrls(ctx, 1, []string{"https://test.com", "https://test2.com"})
+
+			Expect(err).Should(Succeed())
+			Expect(result).Should(Equal([]string{"https://test2.com"}))
+		})
+
+		It("Update success", func() {
+			createTime := time.Now()
+			updateTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET description = \\$1, updated_at = now\\(\\) WHERE deleted_at IS NULL AND id = \\$2 "+
+				"RETURNING "+linkColumnList).
+				WithArgs("new description", 1).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(1, 1, "https://test.com", "https://test.com", "new description", createTime, updateTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery("DELETE FROM link_tags WHERE link_id IN \\(\\$1\\) RETURNING tag_id").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"tag_id"}).AddRow(10).AddRow(11))
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\),\\(\\$2\\)").
+				WithArgs("tag1", "tag2").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "name"}).AddRow(11, "tag1").AddRow(12, "tag2"))
+			dbMock.ExpectExec("INSERT INTO link_tags \\(link_id,tag_id\\) VALUES \\(\\$1,\\$2\\),\\(\\$3,\\$4\\)").
+				WithArgs(1, 11, 1, 12).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectExec("DELETE FROM tags WHERE id IN \\(\\$1,\\$2\\) AND NOT EXISTS \\(SELECT 1 FROM link_tags lt WHERE lt.tag_id = tags.id\\)").
+				WithArgs(10, 11).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(1, "LinkUpdated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			result, err := linkRepo.UpdateEntity(ctx, link.Link{
+				ID:          1,
+				Url:         "https://ignored.com",
+				Description: "new description",
+				Tags:        []string{"tag1", "tag2"},
+			}, []string{"description", "tags"})
+
+			Expect(result).Should(BeEquivalentTo(&link.Link{
+				ID:           1,
+				UserID:       1,
+				Url:          "https://test.com",
+				CanonicalUrl: "https://test.com",
+				Description:  "new description",
+				Tags:         []string{"tag1", "tag2"},
+				CreatedAt:    createTime,
+				UpdatedAt:    updateTime,
+			}))
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Update without tags loads existing tags", func() {
+			updateTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET url = \\$1, canonical_url = \\$2, enriched_at = \\$3, updated_at = now\\(\\) "+
+				"WHERE deleted_at IS NULL AND id = \\$4").
+				WithArgs("https://test.com", "https://test.com", nil, 1).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(1, 1, "https://test.com", "https://test.com", "", updateTime, updateTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(1, "tag1"))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(1, "LinkUpdated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			result, err := linkRepo.UpdateEntity(ctx, link.Link{ID: 1, Url: "https://test.com", CanonicalUrl: "https://test.com"}, []string{"url"})
+
+			Expect(result.Tags).Should(Equal([]string{"tag1"}))
+			Expect(err).Should(Succeed())
+		})
+
+		It("Update error", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET url = \\$1, canonical_url = \\$2, enriched_at = \\$3, updated_at = now\\(\\) "+
+				"WHERE deleted_at IS NULL AND id = \\$4").
+				WithArgs("https://test.com", "https://test.com", nil, 1).
+				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
+
+			result, err := linkRepo.UpdateEntity(ctx, link.Link{ID: 1, Url: "https://test.com", CanonicalUrl: "https://test.com"}, []string{"url"})
+
+			Expect(result).Should(BeNil())
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Update unknown field", func() {
+			result, err := linkRepo.UpdateEntity(ctx, link.Link{ID: 1, UserID: 2}, []string{"user_id"})
+
+			Expect(result).Should(BeNil())
+			Expect(errors.Is(err, repo.ErrInvalidInput)).Should(BeTrue())
+		})
+
+		It("Update not found", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET url = \\$1, canonical_url = \\$2, enriched_at = \\$3, updated_at = now\\(\\) "+
+				"WHERE deleted_at IS NULL AND id = \\$4").
+				WithArgs("https://test.com", "https://test.com", nil, 1).
+				WillReturnError(sql.ErrNoRows)
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.UpdateEntity(ctx, link.Link{ID: 1, Url: "https://test.com", CanonicalUrl: "https://test.com"}, []string{"url"})
+
+			Expect(errors.Is(err, repo.ErrNotFound)).Should(BeTrue())
+		})
+
+		It("List tags success", func() {
+			dbMock.ExpectQuery("SELECT t.name, count\\(\\*\\) AS count FROM tags t " +
+				"JOIN link_tags lt ON lt.tag_id = t.id JOIN links l ON l.id = lt.link_id " +
+				"WHERE l.deleted_at IS NULL AND l.user_id = \\$1 GROUP BY t.name ORDER BY t.name").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"name", "count"}).AddRow("tag1", 3).AddRow("tag2", 1))
+
+			result, err := linkRepo.ListTags(ctx, 1)
+
+			Expect(result).Should(Equal([]repo.TagCount{{Name: "tag1", Count: 3}, {Name: "tag2", Count: 1}}))
+			Expect(err).Should(Succeed())
+		})
+
+		It("Rename tag success", func() {
+			updateTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("SELECT pg_advisory_xact_lock\\(hashtext\\(\\$1\\)\\)").
+				WithArgs("tag:1:go").
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM links WHERE \\(deleted_at IS NULL AND user_id = \\$1 AND "+
+				"EXISTS \\(SELECT 1 FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = links.id AND t.name IN \\(\\$2\\)\\)\\)").
+				WithArgs(1, "go").
+				WillReturnRows(sqlxmock.NewRows([]string{"count"}).AddRow(0))
+			dbMock.ExpectQuery("UPDATE links SET updated_at = now\\(\\) WHERE \\(deleted_at IS NULL AND user_id = \\$1 AND "+
+				"EXISTS \\(SELECT 1 FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = links.id AND t.name IN \\(\\$2\\)\\)\\) "+
+				"RETURNING "+linkColumnList).
+				WithArgs(1, "golang").
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(1, 1, "https://test.com", "https://test.com", "", updateTime, updateTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(1, "golang").AddRow(1, "web"))
+			dbMock.ExpectQuery("DELETE FROM link_tags WHERE link_id IN \\(\\$1\\) RETURNING tag_id").
+				WithArgs(1).
+				WillReturnRows(sqlxmock.NewRows([]string{"tag_id"}).AddRow(1).AddRow(2))
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\),\\(\\$2\\)").
+				WithArgs("go", "web").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "name"}).AddRow(3, "go").AddRow(2, "web"))
+			dbMock.ExpectExec("INSERT INTO link_tags \\(link_id,tag_id\\) VALUES \\(\\$1,\\$2\\),\\(\\$3,\\$4\\)").
+				WithArgs(1, 2, 1, 3).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectExec("DELETE FROM tags WHERE id IN \\(\\$1,\\$2\\) AND NOT EXISTS \\(SELECT 1 FROM link_tags lt WHERE lt.tag_id = tags.id\\)").
+				WithArgs(1, 2).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+				WithArgs(1, "LinkUpdated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			updated, err := linkRepo.RenameTag(ctx, 1, "golang", "go")
+
+			Expect(updated).Should(Equal(uint64(1)))
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Rename tag to existing one", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("SELECT pg_advisory_xact_lock").
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM links").
+				WithArgs(1, "go").
+				WillReturnRows(sqlxmock.NewRows([]string{"count"}).AddRow(2))
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.RenameTag(ctx, 1, "golang", "go")
+
+			Expect(errors.Is(err, repo.ErrConflict)).Should(BeTrue())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Rename unknown tag", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("SELECT pg_advisory_xact_lock").
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM links").
+				WithArgs(1, "go").
+				WillReturnRows(sqlxmock.NewRows([]string{"count"}).AddRow(0))
+			dbMock.ExpectQuery("UPDATE links SET updated_at = now\\(\\)").
+				WithArgs(1, "golang").
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.RenameTag(ctx, 1, "golang", "go")
+
+			Expect(errors.Is(err, repo.ErrNotFound)).Should(BeTrue())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Rename tag left only on links in the trash", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("SELECT pg_advisory_xact_lock").
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM links WHERE \\(deleted_at IS NULL AND user_id = \\$1 AND EXISTS").
+				WithArgs(1, "go").
+				WillReturnRows(sqlxmock.NewRows([]string{"count"}).AddRow(0))
+			dbMock.ExpectQuery("UPDATE links SET updated_at = now\\(\\) WHERE \\(deleted_at IS NULL AND user_id = \\$1 AND EXISTS").
+				WithArgs(1, "golang").
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+			dbMock.ExpectRollback()
+
+			updated, err := linkRepo.RenameTag(ctx, 1, "golang", "go")
+
+			Expect(updated).Should(BeZero())
+			Expect(errors.Is(err, repo.ErrNotFound)).Should(BeTrue())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Merge tags success", func() {
+			updateTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET updated_at = now\\(\\) WHERE \\(deleted_at IS NULL AND user_id = \\$1 AND EXISTS \\(SELECT 1 FROM link_tags lt JOIN tags t "+
+				"ON t.id = lt.tag_id WHERE lt.link_id = links.id AND t.name IN \\(\\$2,\\$3\\)\\)\\)").
+				WithArgs(1, "golang", "go").
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(1, 1, "https://test.com1", "https://test.com1", "", updateTime, updateTime, nil, "", "", "", "", "", nil).
+						AddRow(2, 1, "https://test.com2", "https://test.com2", "", updateTime, updateTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags+"\\(\\$1,\\$2\\) ORDER BY lt.link_id, t.name").
+				WithArgs(1, 2).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(1, "go").AddRow(1, "golang").AddRow(2, "golang"))
+			dbMock.ExpectQuery("DELETE FROM link_tags WHERE link_id IN \\(\\$1,\\$2\\) RETURNING tag_id").
+				WithArgs(1, 2).
+				WillReturnRows(sqlxmock.NewRows([]string{"tag_id"}).AddRow(3).AddRow(4).AddRow(4))
+			dbMock.ExpectQuery("INSERT INTO tags \\(name\\) VALUES \\(\\$1\\)").
+				WithArgs("go").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "name"}).AddRow(3, "go"))
+			dbMock.ExpectExec("INSERT INTO link_tags \\(link_id,tag_id\\) VALUES \\(\\$1,\\$2\\),\\(\\$3,\\$4\\)").
+				WithArgs(1, 3, 2, 3).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectExec("DELETE FROM tags WHERE id IN \\(\\$1,\\$2\\) AND NOT EXISTS").
+				WithArgs(3, 4).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\),\\(\\$4,\\$5,\\$6\\)").
+				WithArgs(1, "LinkUpdated", sqlxmock.AnyArg(), 2, "LinkUpdated", sqlxmock.AnyArg()).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectCommit()
+
+			updated, err := linkRepo.MergeTags(ctx, 1, []string{"golang", "go"}, "go")
+
+			Expect(updated).Should(Equal(uint64(2)))
+			Expect(err).Should(Succeed())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Merge tags error rolls back", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("UPDATE links SET updated_at = now\\(\\)").
+				WithArgs(1, "golang").
+				WillReturnError(errors.New("something goes wrong"))
+			dbMock.ExpectRollback()
+
+			_, err := linkRepo.MergeTags(ctx, 1, []string{"golang"}, "go")
+
+			Expect(err).Should(HaveOccurred())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("List entities to enrich", func() {
+			createTime := time.Now()
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links " +
+				"WHERE deleted_at IS NULL AND enriched_at IS NULL ORDER BY id LIMIT 10").
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(1, 1, "https://test.com", "https://test.com", "", createTime, createTime, nil, "", "", "", "", "", nil),
+				)
+
+			result, err := linkRepo.ListEntitiesToEnrich(ctx, 10)
+
+			Expect(result).Should(HaveLen(1))
+			Expect(err).Should(Succeed())
+		})
+
+		It("Update metadata success", func() {
+			dbMock.ExpectExec("UPDATE links SET title = \\$1, page_description = \\$2, image_url = \\$3, favicon_url = \\$4, "+
+				"page_canonical_url = \\$5, enriched_at = now\\(\\) WHERE id = \\$6 AND url = \\$7").
+				WithArgs("Title", "Description", "https://test.com/image.png", "https://test.com/favicon.ico", "https://test.com", 1, "https://test.com").
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+
+			err := linkRepo.UpdateEntityMetadata(ctx, 1, "https://test.com", link.Metadata{
+				Title:            "Title",
+				PageDescription:  "Description",
+				ImageUrl:         "https://test.com/image.png",
+				FaviconUrl:       "https://test.com/favicon.ico",
+				PageCanonicalUrl: "https://test.com",
+			})
+
+			Expect(err).Should(Succeed())
+		})
+
+		It("Update metadata of purged link or changed url", func() {
+			dbMock.ExpectExec("UPDATE links SET title = \\$1").
+				WillReturnResult(sqlxmock.NewResult(0, 0))
+
+			err := linkRepo.UpdateEntityMetadata(ctx, 1, "https://old.com", link.Metadata{})
+
+			Expect(errors.Is(err, repo.ErrNotFound)).Should(BeTrue())
+		})
+
+		It("List to check success", func() {
+			selectTime := time.Now()
+			checkedBefore := selectTime.Add(-24 * time.Hour)
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links WHERE deleted_at IS NULL AND id > \\$1 "+
+				"AND NOT EXISTS \\(SELECT 1 FROM link_checks WHERE link_checks.link_id = links.id AND link_checks.checked_at >= \\$2\\) "+
+				"ORDER BY id LIMIT 10").
+				WithArgs(5, checkedBefore).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(6, 1, "https://test.com", "https://test.com", "", selectTime, selectTime, nil, "", "", "", "", "", nil),
+				)
+
+			result, err := linkRepo.ListEntitiesToCheck(ctx, checkedBefore, 5, 10)
+
+			Expect(result).Should(HaveLen(1))
+			Expect(err).Should(Succeed())
+		})
+
+		It("Add checks success", func() {
+			checkTime := time.Now()
+			dbMock.ExpectBegin()
+			dbMock.ExpectExec("INSERT INTO link_checks \\(link_id,status_code,final_url,error,checked_at\\) "+
+				"VALUES \\(\\$1,\\$2,\\$3,\\$4,\\$5\\),\\(\\$6,\\$7,\\$8,\\$9,\\$10\\)").
+				WithArgs(1, 200, "https://test.com/", "", checkTime, 2, 0, "https://test2.com", "connection refused", checkTime).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectExec("DELETE FROM link_checks WHERE id IN \\(SELECT id FROM \\(SELECT id, row_number\\(\\) OVER "+
+				"\\(PARTITION BY link_id ORDER BY checked_at DESC\\) AS position FROM link_checks WHERE link_id IN \\(\\$1,\\$2\\)\\) c "+
+				"WHERE position > \\$3\\)").
+				WithArgs(1, 2, 30).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectCommit()
+
+			err := linkRepo.AddChecks(ctx, []link.Check{
+				{LinkID: 1, StatusCode: 200, FinalUrl: "https://test.com/", CheckedAt: checkTime},
+				{LinkID: 2, FinalUrl: "https://test2.com", Error: "connection refused", CheckedAt: checkTime},
+			})
+
+			Expect(err).Should(Succeed())
+		})
+
+		It("Add no checks", func() {
+			Expect(linkRepo.AddChecks(ctx, nil)).Should(Succeed())
+		})
+
+		It("List broken success", func() {
+			selectTime := time.Now()
+			dbMock.ExpectQuery("SELECT "+linkColumnList+" FROM links "+
+				"JOIN LATERAL \\(SELECT status_code, error, checked_at FROM link_checks "+
+				"WHERE link_checks.link_id = links.id ORDER BY checked_at DESC LIMIT 1\\) c ON true "+
+				"WHERE \\(deleted_at IS NULL AND \\(c.error <> \\$1 OR c.status_code >= \\$2\\) AND user_id = \\$3\\) "+
+				"ORDER BY c.checked_at DESC, id LIMIT 10 OFFSET 0").
+				WithArgs("", 400, 1).
+				WillReturnRows(
+					sqlxmock.
+						NewRows(linkColumns).
+						AddRow(2, 1, "https://test2.com", "https://test2.com", "", selectTime, selectTime, nil, "", "", "", "", "", nil),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(2).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}))
+			dbMock.ExpectQuery("SELECT DISTINCT ON \\(link_id\\) link_id, status_code, final_url, error, checked_at FROM link_checks").
+				WithArgs(2).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "status_code", "final_url", "error", "checked_at"}).
+					AddRow(2, 404, "https://test2.com", "", selectTime))
+
+			result, err := linkRepo.ListBrokenEntities(ctx, 1, 10, 0)
+
+			Expect(err).Should(Succeed())
+			Expect(result).Should(HaveLen(1))
+			Expect(result[0].LastCheck.Broken()).Should(BeTrue())
+		})
+
+		It("Search success", func() {
+			selectTime := time.Now()
+			dbMock.ExpectQuery("SELECT "+linkColumnList+", ts_rank\\(search_vector, q.query\\) AS rank, "+
+				"ts_headline\\('english', concat_ws\\(' ', title, description\\), q.query, '.*'\\) AS headline "+
+				"FROM links CROSS JOIN \\(SELECT plainto_tsquery\\('english', \\$1\\) && "+
+				"phraseto_tsquery\\('english', \\$2\\) && !!phraseto_tsquery\\('english', \\$3\\) AS query\\) q "+
+				"WHERE \\(deleted_at IS NULL AND user_id = \\$4 AND "+
+				"EXISTS \\(SELECT 1 FROM link_tags lt JOIN tags t ON t.id = lt.tag_id WHERE lt.link_id = links.id AND t.name = \\$5\\) AND "+
+				"search_vector @@ q.query\\) ORDER BY rank DESC, id LIMIT 10 OFFSET 20").
+				WithArgs("golang generics", "error handling", "java", 1, "go").
+				WillReturnRows(
+					sqlxmock.
+						NewRows(append(linkColumns, "rank", "headline")).
+						AddRow(2, 1, "https://go.dev", "https://go.dev", "", selectTime, selectTime, nil, "Go", "", "", "", "", nil,
+							0.75, "<b>Go</b> generics"),
+				)
+			dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+				WithArgs(2).
+				WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(2, "go"))
+
+			result, err := linkRepo.SearchEntities(ctx,
+				repo.SearchQuery{
+					Words:    []string{"golang", "generics"},
+					Phrases:  []string{"error handling"},
+					Excluded: []string{"java"},
+				},
+				repo.Filter{UserID: 1, Tags: []string{"go"}, MatchAllTags: true},
+				10, 20,
+			)
+
+			Expect(err).Should(Succeed())
+			Expect(result).Should(HaveLen(1))
+			Expect(result[0].ID).Should(Equal(uint64(2)))
+			Expect(result[0].Rank).Should(Equal(0.75))
+			Expect(result[0].Headline).Should(Equal("<b>Go</b> generics"))
+			Expect(result[0].Tags).Should(Equal([]string{"go"}))
+		})
+
+		It("Search by tags only", func() {
+			dbMock.ExpectQuery("SELECT " + linkColumnList + ", 0 AS rank, title AS headline FROM links " +
+				"WHERE \\(deleted_at IS NULL AND EXISTS .* t.name = \\$1\\)\\) ORDER BY rank DESC, id LIMIT 10 OFFSET 0").
+				WithArgs("go").
+				WillReturnRows(sqlxmock.NewRows(append(linkColumns, "rank", "headline")))
+
+			result, err := linkRepo.SearchEntities(ctx, repo.SearchQuery{}, repo.Filter{Tags: []string{"go"}, MatchAllTags: true}, 10, 0)
+
+			Expect(err).Should(Succeed())
+			Expect(result).Should(BeEmpty())
+		})
+
+		It("Publish events", func() {
+			createTime := time.Now()
+			dbMock.ExpectQuery("SELECT pg_try_advisory_lock\\(\\$1\\)").
+				WillReturnRows(sqlxmock.NewRows([]string{"locked"}).AddRow(true))
+			dbMock.ExpectQuery("SELECT id, link_id, event_type, payload, created_at FROM outbox ORDER BY id LIMIT 10$").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "link_id", "event_type", "payload", "created_at"}).
+					AddRow(1, 7, "LinkCreated", `{"id":7}`, createTime).
+					AddRow(2, 7, "LinkUpdated", `{"id":7}`, createTime).
+					AddRow(3, 8, "LinkCreated", `{"id":8}`, createTime))
+			dbMock.ExpectExec("DELETE FROM outbox WHERE id IN \\(\\$1,\\$2\\)").
+				WithArgs(1, 2).
+				WillReturnResult(sqlxmock.NewResult(0, 2))
+			dbMock.ExpectQuery("SELECT pg_advisory_unlock\\(\\$1\\)").
+				WillReturnRows(sqlxmock.NewRows([]string{"unlocked"}).AddRow(true))
+
+			publishErr := errors.New("broker is down")
+			var received []event.Event
+			published, err := linkRepo.PublishEvents(ctx, 10, func(ctx context.Context, events []event.Event) (uint64, error) {
+				received = events
+				return 2, publishErr
+			})
+
+			Expect(err).Should(MatchError(publishErr))
+			Expect(published).Should(Equal(uint64(2)))
+			Expect(received).Should(HaveLen(3))
+			Expect(received[1].Type).Should(Equal(event.LinkUpdated))
+			Expect(string(received[2].Payload)).Should(Equal(`{"id":8}`))
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Publish without events", func() {
+			dbMock.ExpectQuery("SELECT pg_try_advisory_lock\\(\\$1\\)").
+				WillReturnRows(sqlxmock.NewRows([]string{"locked"}).AddRow(true))
+			dbMock.ExpectQuery("SELECT id, link_id, event_type, payload, created_at FROM outbox").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "link_id", "event_type", "payload", "created_at"}))
+			dbMock.ExpectQuery("SELECT pg_advisory_unlock\\(\\$1\\)").
+				WillReturnRows(sqlxmock.NewRows([]string{"unlocked"}).AddRow(true))
+
+			published, err := linkRepo.PublishEvents(ctx, 10, func(ctx context.Context, events []event.Event) (uint64, error) {
+				Fail("nothing to publish")
+				return 0, nil
+			})
+
+			Expect(err).Should(Succeed())
+			Expect(published).Should(BeZero())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Publish while another caller publishes", func() {
+			dbMock.ExpectQuery("SELECT pg_try_advisory_lock\\(\\$1\\)").
+				WillReturnRows(sqlxmock.NewRows([]string{"locked"}).AddRow(false))
+
+			published, err := linkRepo.PublishEvents(ctx, 10, func(ctx context.Context, events []event.Event) (uint64, error) {
+				Fail("the outbox is locked")
+				return 0, nil
+			})
+
+			Expect(err).Should(Succeed())
+			Expect(published).Should(BeZero())
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("Publish timed out after some events were sent", func() {
+			linkRepo = repo.NewLinkRepo(db, repo.Timeouts{Batch: 20 * time.Millisecond, Write: time.Second})
+			createTime := time.Now()
+			dbMock.ExpectQuery("SELECT pg_try_advisory_lock\\(\\$1\\)").
+				WillReturnRows(sqlxmock.NewRows([]string{"locked"}).AddRow(true))
+			dbMock.ExpectQuery("SELECT id, link_id, event_type, payload, created_at FROM outbox").
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "link_id", "event_type", "payload", "created_at"}).
+					AddRow(1, 7, "LinkCreated", `{"id":7}`, createTime).
+					AddRow(2, 8, "LinkCreated", `{"id":8}`, createTime))
+			dbMock.ExpectExec("DELETE FROM outbox WHERE id IN \\(\\$1\\)").
+				WithArgs(1).
+				WillReturnResult(sqlxmock.NewResult(0, 1))
+			dbMock.ExpectQuery("SELECT pg_advisory_unlock\\(\\$1\\)").
+				WillReturnRows(sqlxmock.NewRows([]string{"unlocked"}).AddRow(true))
+
+			published, err := linkRepo.PublishEvents(ctx, 10, func(ctx context.Context, events []event.Event) (uint64, error) {
+				<-ctx.Done()
+				return 1, ctx.Err()
+			})
+
+			Expect(errors.Is(err, context.DeadlineExceeded)).Should(BeTrue())
+			Expect(published).Should(Equal(uint64(1)))
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
+		})
+
+		It("List canceled by the caller", func() {
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links").
+				WillDelayFor(time.Second).
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+
+			ctx, cancel := context.WithCancel(ctx)
+			time.AfterFunc(20*time.Millisecond, cancel)
+			started := time.Now()
+			_, err := linkRepo.ListEntities(ctx, repo.Filter{}, 10, 0)
+
+			Expect(err).Should(MatchError(context.Canceled))
+			Expect(time.Since(started)).Should(BeNumerically("<", 500*time.Millisecond))
+		})
+
+		It("List timed out", func() {
+			linkRepo = repo.NewLinkRepo(db, repo.Timeouts{Read: 20 * time.Millisecond})
+			dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links").
+				WillDelayFor(time.Second).
+				WillReturnRows(sqlxmock.NewRows(linkColumns))
+
+			_, err := linkRepo.ListEntities(ctx, repo.Filter{}, 10, 0)
+
+			Expect(err).Should(MatchError(context.DeadlineExceeded))
+			Expect(errors.Is(err, repo.ErrUnavailable)).Should(BeFalse())
+		})
+
+		It("Timeout of another kind of operation does not apply", func() {
+			linkRepo = repo.NewLinkRepo(db, repo.Timeouts{Write: time.Nanosecond})
+			dbMock.ExpectQuery("SELECT count\\(\\*\\) FROM links").
+				WillDelayFor(20 * time.Millisecond).
+				WillReturnRows(sqlxmock.NewRows([]string{"count"}).AddRow(3))
+
+			count, err := linkRepo.CountEntities(ctx, repo.Filter{})
+
+			Expect(err).Should(Succeed())
+			Expect(count).Should(Equal(uint64(3)))
+		})
+
+		It("Create canceled in the middle of a transaction", func() {
+			dbMock.ExpectBegin()
+			dbMock.ExpectQuery("INSERT INTO links").
+				WillDelayFor(time.Second).
+				WillReturnRows(sqlxmock.NewRows([]string{"id", "user_id", "canonical_url"}))
+			dbMock.ExpectRollback()
+
+			ctx, cancel := context.WithCancel(ctx)
+			time.AfterFunc(20*time.Millisecond, cancel)
+			err := linkRepo.AddEntities(ctx, []link.Link{*link.New(1, "https://test.com")})
+
+			Expect(err).Should(MatchError(context.Canceled))
+			Eventually(dbMock.ExpectationsWereMet).Should(Succeed())
+		})
+
+		It("Create with a canceled context", func() {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := linkRepo.AddEntity(ctx, *link.New(1, "https://test.com"))
+
+			Expect(err).Should(MatchError(context.Canceled))
+			Expect(dbMock.ExpectationsWereMet()).Should(Succeed())
 		})
 	})
 })
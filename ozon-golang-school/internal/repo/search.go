@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"strings"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+type SearchQuery struct {
+	Words    []string
+	Phrases  []string
+	Excluded []string
+}
+
+type SearchResult struct {
+	link.Link
+	Rank     float64 `db:"rank"`
+	Headline string  `db:"headline"`
+}
+
+const searchConfig = "'english'"
+
+const headlineOptions = "'StartSel=<b>, StopSel=</b>, MaxFragments=2, MinWords=5, MaxWords=20'"
+
+func (q SearchQuery) IsEmpty() bool {
+	return len(q.Words) == 0 && len(q.Phrases) == 0 && len(q.Excluded) == 0
+}
+
+func (q SearchQuery) tsQuery() (string, []interface{}) {
+	parts := make([]string, 0, 1+len(q.Phrases)+len(q.Excluded))
+	params := make([]interface{}, 0, cap(parts))
+
+	if len(q.Words) > 0 {
+		parts = append(parts, "plainto_tsquery("+searchConfig+", ?)")
+		params = append(params, strings.Join(q.Words, " "))
+	}
+	for _, phrase := range q.Phrases {
+		parts = append(parts, "phraseto_tsquery("+searchConfig+", ?)")
+		params = append(params, phrase)
+	}
+	for _, excluded := range q.Excluded {
+		parts = append(parts, "!!phraseto_tsquery("+searchConfig+", ?)")
+		params = append(params, excluded)
+	}
+
+	return strings.Join(parts, " && "), params
+}
+
+func searchColumns(query SearchQuery) []string {
+	columns := append([]string{}, linkColumns...)
+	if query.IsEmpty() {
+		return append(columns, "0 AS rank", "title AS headline")
+	}
+
+	return append(columns,
+		"ts_rank(search_vector, q.query) AS rank",
+		"ts_headline("+searchConfig+", concat_ws(' ', title, description), q.query, "+headlineOptions+") AS headline",
+	)
+}
@@ -0,0 +1,259 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+type TagCount struct {
+	Name  string
+	Count uint64
+}
+
+type linkTag struct {
+	LinkID uint64 `db:"link_id"`
+	Name   string
+}
+
+type tagID struct {
+	ID   uint64
+	Name string
+}
+
+func loadTags(ctx context.Context, q sqlx.QueryerContext, entities ...*link.Link) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.ID)
+	}
+
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select("lt.link_id", "t.name").
+		From("link_tags lt").
+		Join("tags t ON t.id = lt.tag_id").
+		Where(squirrel.Eq{"lt.link_id": ids}).
+		OrderBy("lt.link_id", "t.name").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows := make([]linkTag, 0, len(entities))
+	if err := selectRows(ctx, q, &rows, sql, params...); err != nil {
+		return err
+	}
+
+	tags := make(map[uint64][]string, len(entities))
+	for _, row := range rows {
+		tags[row.LinkID] = append(tags[row.LinkID], row.Name)
+	}
+	for _, entity := range entities {
+		entity.Tags = tags[entity.ID]
+	}
+
+	return nil
+}
+
+func saveTags(ctx context.Context, tx sqlx.ExtContext, entities ...*link.Link) error {
+	sql, params, err := upsertTagsQuery(entities)
+	if err != nil || sql == "" {
+		return err
+	}
+
+	tagIDs := make([]tagID, 0, len(params))
+	if err := selectRows(ctx, tx, &tagIDs, sql, params...); err != nil {
+		return err
+	}
+
+	sql, params, err = linkTagsQuery(entities, tagIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = exec(ctx, tx, sql, params...)
+	return err
+}
+
+// upsertTagsQuery returns the ids of the tags of entities creating the missing ones, an empty query when there are no tags.
+func upsertTagsQuery(entities []*link.Link) (string, []interface{}, error) {
+	unique := make(map[string]bool)
+	for _, entity := range entities {
+		for _, tag := range entity.Tags {
+			unique[tag] = true
+		}
+	}
+	if len(unique) == 0 {
+		return "", nil, nil
+	}
+
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tagsBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("tags").
+		Columns("name").
+		Suffix("ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name RETURNING id, name")
+	for _, name := range names {
+		tagsBuilder = tagsBuilder.Values(name)
+	}
+	return tagsBuilder.ToSql()
+}
+
+func linkTagsQuery(entities []*link.Link, tagIDs []tagID) (string, []interface{}, error) {
+	ids := make(map[string]uint64, len(tagIDs))
+	for _, tag := range tagIDs {
+		ids[tag.Name] = tag.ID
+	}
+
+	linkTagsBuilder := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Insert("link_tags").
+		Columns("link_id", "tag_id").
+		Suffix("ON CONFLICT DO NOTHING")
+	for _, entity := range entities {
+		for _, tag := range entity.Tags {
+			linkTagsBuilder = linkTagsBuilder.Values(entity.ID, ids[tag])
+		}
+	}
+	return linkTagsBuilder.ToSql()
+}
+
+// replaceTags also deletes the tags the entities no longer carry if no other link has them.
+func replaceTags(ctx context.Context, tx sqlx.ExtContext, entities ...*link.Link) error {
+	ids := make([]uint64, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.ID)
+	}
+
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Delete("link_tags").
+		Where(squirrel.Eq{"link_id": ids}).
+		Suffix("RETURNING tag_id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	removed := make([]uint64, 0)
+	if err := selectRows(ctx, tx, &removed, sql, params...); err != nil {
+		return err
+	}
+
+	if err := saveTags(ctx, tx, entities...); err != nil {
+		return err
+	}
+	return deleteUnusedTags(ctx, tx, removed)
+}
+
+func deleteUnusedTags(ctx context.Context, tx sqlx.ExecerContext, tagIDs []uint64) error {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	unique := make(map[uint64]bool, len(tagIDs))
+	ids := make([]uint64, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		if !unique[id] {
+			unique[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Delete("tags").
+		Where(squirrel.Eq{"id": ids}).
+		Where("NOT EXISTS (SELECT 1 FROM link_tags lt WHERE lt.tag_id = tags.id)").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = exec(ctx, tx, sql, params...)
+	return err
+}
+
+func countTagged(ctx context.Context, q sqlx.QueryerContext, userID uint64, name string) (uint64, error) {
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Select("count(*)").
+		From("links").
+		Where(taggedWhere(userID, []string{name})).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var result uint64
+	err = getRow(ctx, q, &result, sql, params...)
+	return result, err
+}
+
+// lockTagName makes renames of one user into name wait for each other until commit, there may
+// be no rows to lock yet, so the check that name is not in use would pass for all of them.
+func lockTagName(ctx context.Context, tx sqlx.ExecerContext, userID uint64, name string) error {
+	_, err := exec(ctx, tx, "SELECT pg_advisory_xact_lock(hashtext($1))", fmt.Sprintf("tag:%d:%s", userID, name))
+	return err
+}
+
+func lockTagged(ctx context.Context, tx sqlx.ExtContext, userID uint64, names []string) ([]*link.Link, error) {
+	sql, params, err := squirrel.StatementBuilder.
+		PlaceholderFormat(squirrel.Dollar).
+		Update("links").
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(taggedWhere(userID, names)).
+		Suffix("RETURNING " + strings.Join(linkColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]link.Link, 0)
+	if err := selectRows(ctx, tx, &entities, sql, params...); err != nil {
+		return nil, err
+	}
+
+	result := linkPointers(entities)
+	if err := loadTags(ctx, tx, result...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func retag(ctx context.Context, tx sqlx.ExtContext, entities []*link.Link, names []string, target string) error {
+	for _, entity := range entities {
+		for _, name := range names {
+			entity.RemoveTag(name)
+		}
+		entity.AddTag(target)
+	}
+
+	return replaceTags(ctx, tx, entities...)
+}
+
+// taggedWhere leaves links in the trash out, ListTags does not count them either.
+// Tags are renamed and merged for one user at a time, a zero userID matches no links.
+func taggedWhere(userID uint64, names []string) squirrel.And {
+	return squirrel.And{
+		squirrel.Eq{"deleted_at": nil},
+		squirrel.Eq{"user_id": userID},
+		hasAnyTag(names),
+	}
+}
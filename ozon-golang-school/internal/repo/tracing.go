@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ozonva/ova-link-api/internal/repo"
+
+// RowsKey is the number of rows a query returned or affected.
+const RowsKey = attribute.Key("db.rows")
+
+// startSpan opens the span of a repo method. Time between it and its query spans is spent building SQL.
+func startSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "repo."+method)
+}
+
+func finishSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "sql",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBStatementKey.String(query)),
+	)
+}
+
+func selectRows(ctx context.Context, q sqlx.QueryerContext, dest interface{}, query string, params ...interface{}) error {
+	ctx, span := startQuerySpan(ctx, query)
+	err := sqlx.SelectContext(ctx, q, dest, query, params...)
+	if err == nil {
+		span.SetAttributes(RowsKey.Int(reflect.ValueOf(dest).Elem().Len()))
+	}
+	finishSpan(span, err)
+	return err
+}
+
+func getRow(ctx context.Context, q sqlx.QueryerContext, dest interface{}, query string, params ...interface{}) error {
+	ctx, span := startQuerySpan(ctx, query)
+	err := sqlx.GetContext(ctx, q, dest, query, params...)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Callers expect no rows in many places, it is not a failed query.
+		span.SetAttributes(RowsKey.Int(0))
+		span.End()
+		return err
+	}
+	if err == nil {
+		span.SetAttributes(RowsKey.Int(1))
+	}
+	finishSpan(span, err)
+	return err
+}
+
+func exec(ctx context.Context, e sqlx.ExecerContext, query string, params ...interface{}) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	result, err := e.ExecContext(ctx, query, params...)
+	if err == nil {
+		if affected, err := result.RowsAffected(); err == nil {
+			span.SetAttributes(RowsKey.Int64(affected))
+		}
+	}
+	finishSpan(span, err)
+	return result, err
+}
@@ -0,0 +1,122 @@
+package repo_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/repo"
+	"github.com/ozonva/ova-link-api/internal/tracing/tracingtest"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	result := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		result[kv.Key] = kv.Value
+	}
+	return result
+}
+
+var _ = Describe("Tracing", func() {
+	var linkRepo repo.Repo
+	var dbMock sqlxmock.Sqlmock
+	var exporter *tracetest.InMemoryExporter
+	var ctx context.Context
+	var root trace.Span
+
+	BeforeEach(func() {
+		var provider trace.TracerProvider
+		provider, exporter = tracingtest.NewProvider()
+		otel.SetTracerProvider(provider)
+		ctx, root = provider.Tracer("test").Start(context.Background(), "rpc")
+
+		db, mock, err := sqlxmock.Newx()
+		Expect(err).ShouldNot(HaveOccurred())
+		dbMock = mock
+		linkRepo = repo.NewLinkRepo(db, repo.Timeouts{})
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+
+	It("List. Should trace the method and every query with its rows.", func() {
+		selectTime := time.Now()
+		dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE \\(deleted_at IS NULL\\) ORDER BY id LIMIT 2 OFFSET 0").
+			WillReturnRows(sqlxmock.NewRows(linkColumns).
+				AddRow(3, 1, "https://test.com3", "https://test.com3", "", selectTime, selectTime, nil, "", "", "", "", "", nil).
+				AddRow(4, 1, "https://test.com4", "https://test.com4", "", selectTime, selectTime, nil, "", "", "", "", "", nil))
+		dbMock.ExpectQuery(selectTags+"\\(\\$1,\\$2\\) ORDER BY lt.link_id, t.name").
+			WithArgs(3, 4).
+			WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}).AddRow(3, "tag3"))
+
+		_, err := linkRepo.ListEntities(ctx, repo.Filter{}, 2, 0)
+		root.End()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		spans := exporter.GetSpans()
+		Expect(spans).Should(HaveLen(4))
+		links, tags, method := spans[0], spans[1], spans[2]
+
+		Expect(method.Name).Should(Equal("repo.ListEntities"))
+		Expect(method.Parent.SpanID()).Should(Equal(root.SpanContext().SpanID()))
+
+		Expect(links.Name).Should(Equal("sql"))
+		Expect(links.Parent.SpanID()).Should(Equal(method.SpanContext.SpanID()))
+		Expect(spanAttributes(links)).Should(And(
+			HaveKeyWithValue(attribute.Key("db.system"), attribute.StringValue("postgresql")),
+			HaveKeyWithValue(attribute.Key("db.statement"), attribute.StringValue(
+				"SELECT "+linkColumnList+" FROM links WHERE (deleted_at IS NULL) ORDER BY id LIMIT 2 OFFSET 0")),
+			HaveKeyWithValue(repo.RowsKey, attribute.IntValue(2)),
+		))
+		Expect(tags.Parent.SpanID()).Should(Equal(method.SpanContext.SpanID()))
+		Expect(spanAttributes(tags)).Should(HaveKeyWithValue(repo.RowsKey, attribute.IntValue(1)))
+	})
+
+	It("Delete in a transaction. Should trace the commit and affected rows.", func() {
+		dbMock.ExpectBegin()
+		dbMock.ExpectQuery("UPDATE links SET deleted_at = now\\(\\) WHERE deleted_at IS NULL AND id = \\$1 RETURNING " + linkColumnList).
+			WithArgs(1).
+			WillReturnRows(sqlxmock.NewRows(linkColumns).
+				AddRow(1, 1, "https://test.com", "https://test.com", "", time.Now(), time.Now(), time.Now(), "", "", "", "", "", nil))
+		dbMock.ExpectQuery(selectTags + "\\(\\$1\\) ORDER BY lt.link_id, t.name").
+			WithArgs(1).
+			WillReturnRows(sqlxmock.NewRows([]string{"link_id", "name"}))
+		dbMock.ExpectExec(insertOutbox+"\\(\\$1,\\$2,\\$3\\)").
+			WithArgs(1, "LinkDeleted", sqlxmock.AnyArg()).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		dbMock.ExpectCommit()
+
+		Expect(linkRepo.DeleteEntity(ctx, 1)).Should(Succeed())
+
+		spans := exporter.GetSpans()
+		Expect(spans).Should(HaveLen(5))
+		Expect(spanAttributes(spans[2])).Should(HaveKeyWithValue(repo.RowsKey, attribute.Int64Value(1)))
+		Expect(spanAttributes(spans[3])).Should(HaveKeyWithValue(attribute.Key("db.statement"), attribute.StringValue("COMMIT")))
+		Expect(spans[4].Name).Should(Equal("repo.DeleteEntity"))
+	})
+
+	It("Describe not found. Should mark only the method span as failed.", func() {
+		dbMock.ExpectQuery("SELECT " + linkColumnList + " FROM links WHERE id = \\$1 AND deleted_at IS NULL").
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := linkRepo.DescribeEntity(ctx, 1)
+		Expect(err).Should(HaveOccurred())
+
+		spans := exporter.GetSpans()
+		Expect(spans).Should(HaveLen(2))
+		Expect(spans[0].Status.Code).Should(Equal(codes.Unset))
+		Expect(spanAttributes(spans[0])).Should(HaveKeyWithValue(repo.RowsKey, attribute.IntValue(0)))
+		Expect(spans[1].Name).Should(Equal("repo.DescribeEntity"))
+		Expect(spans[1].Status.Code).Should(Equal(codes.Error))
+	})
+})
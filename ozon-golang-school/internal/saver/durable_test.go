@@ -0,0 +1,204 @@
+package saver_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/flusher"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
+	"github.com/ozonva/ova-link-api/internal/mocks"
+	"github.com/ozonva/ova-link-api/internal/saver"
+	"github.com/ozonva/ova-link-api/internal/wal"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+const crashDirEnv = "SAVER_CRASH_DIR"
+
+const crashFlushing = "flushing"
+
+func newLinks(from int, to int) []link.Link {
+	result := make([]link.Link, 0, to-from)
+	for i := from; i < to; i++ {
+		result = append(result, *link.New(1, "https://test.com/"+strconv.Itoa(i)))
+	}
+	return result
+}
+
+func urls(entities []link.Link) []string {
+	result := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.Url)
+	}
+	return result
+}
+
+// hangingFlusher persists the first flush and hangs in the next one until the process is killed.
+type hangingFlusher struct {
+	flushes int
+	hung    chan bool
+}
+
+func (f *hangingFlusher) Flush(ctx context.Context, entities []link.Link) []link.Link {
+	f.flushes++
+	if f.flushes == 1 {
+		return nil
+	}
+	close(f.hung)
+	select {}
+}
+
+// runCrashingSaver saves links 1-5 with capacity 2: links 1 and 2 are flushed, the flush of
+// 3 and 4 never ends and 5 is saved while it hangs.
+func runCrashingSaver(dir string) {
+	log, err := wal.Open(dir, wal.Options{Sync: wal.SyncAlways})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	f := &hangingFlusher{hung: make(chan bool)}
+	s := saver.NewDurableSaver(2, 2, saver.OverflowBlock, f, 3600, metrics.New(prometheus.NewRegistry()), log, zerolog.Nop())
+	s.SaveBatch(context.Background(), newLinks(1, 4))
+	s.Save(context.Background(), newLinks(4, 5)[0])
+	<-f.hung
+	s.Save(context.Background(), newLinks(5, 6)[0])
+	fmt.Println(crashFlushing)
+	select {}
+}
+
+var _ = Describe("Durable saver", func() {
+	var ctrl *gomock.Controller
+	var repo *mocks.MockRepo
+	var saverMetrics *metrics.Metrics
+	var dir string
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		repo = mocks.NewMockRepo(ctrl)
+		saverMetrics = metrics.New(prometheus.NewRegistry())
+
+		var err error
+		dir, err = ioutil.TempDir("", "saver")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		Expect(os.RemoveAll(dir)).Should(Succeed())
+	})
+
+	openLog := func() *wal.Log {
+		log, err := wal.Open(dir, wal.Options{Sync: wal.SyncAlways})
+		Expect(err).ShouldNot(HaveOccurred())
+		return log
+	}
+
+	newSaver := func(log *wal.Log) saver.Saver {
+		return saver.NewDurableSaver(5, 5, saver.OverflowBlock, flusher.NewFlusher(3, repo, saverMetrics), 3600, saverMetrics, log, zerolog.Nop())
+	}
+
+	It("Links left in the log. Should flush them on start and truncate the log.", func() {
+		log := openLog()
+		Expect(log.Append(newLinks(1, 5)...)).Should(Succeed())
+		Expect(log.Close()).Should(Succeed())
+
+		var saved []string
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(ctx context.Context, entities []link.Link) error {
+				saved = append(saved, urls(entities)...)
+				return nil
+			})
+
+		log = openLog()
+		newSaver(log).Close(context.Background())
+		Expect(log.Close()).Should(Succeed())
+		Expect(saved).Should(Equal(urls(newLinks(1, 5))))
+
+		log = openLog()
+		defer log.Close()
+		Expect(log.Replay()).Should(BeEmpty())
+	})
+
+	It("Flush failed. Should keep only unprocessed links in the log.", func() {
+		gomock.InOrder(
+			repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(3)).Return(nil),
+			repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(1)).Return(fmt.Errorf("connection refused")),
+		)
+
+		log := openLog()
+		s := newSaver(log)
+		s.SaveBatch(context.Background(), newLinks(1, 5))
+		s.Close(context.Background())
+		Expect(log.Close()).Should(Succeed())
+
+		log = openLog()
+		defer log.Close()
+		Expect(urls(log.Replay())).Should(Equal(urls(newLinks(4, 5))))
+	})
+
+	It("Flush failed with the queue over capacity. Should not rewrite the log on every save.", func() {
+		segments := func() []string {
+			files, err := ioutil.ReadDir(dir)
+			Expect(err).ShouldNot(HaveOccurred())
+			names := make([]string, 0, len(files))
+			for _, file := range files {
+				names = append(names, file.Name())
+			}
+			return names
+		}
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Return(fmt.Errorf("connection refused")).MinTimes(1)
+
+		log := openLog()
+		s := saver.NewDurableSaver(2, 100, saver.OverflowBlock, flusher.NewFlusher(3, repo, saverMetrics), 3600, saverMetrics, log, zerolog.Nop())
+		started := segments()
+		for _, entity := range newLinks(1, 10) {
+			Expect(s.Save(context.Background(), entity)).Should(Succeed())
+		}
+
+		Consistently(segments, 200*time.Millisecond).Should(Equal(started))
+		s.Close(context.Background())
+		Expect(log.Close()).Should(Succeed())
+
+		log = openLog()
+		defer log.Close()
+		Expect(urls(log.Replay())).Should(Equal(urls(newLinks(1, 10))))
+	})
+
+	It("Process killed in the middle of a flush. Should replay links that were not persisted.", func() {
+		cmd := exec.Command(os.Args[0], "-test.run=TestSaver")
+		cmd.Env = append(os.Environ(), crashDirEnv+"="+dir)
+		stdout, err := cmd.StdoutPipe()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cmd.Start()).Should(Succeed())
+
+		line, err := bufio.NewReader(stdout).ReadString('\n')
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(strings.TrimSpace(line)).Should(Equal(crashFlushing))
+		Expect(cmd.Process.Kill()).Should(Succeed())
+		Expect(cmd.Wait()).ShouldNot(Succeed())
+
+		var saved []string
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(ctx context.Context, entities []link.Link) error {
+				saved = append(saved, urls(entities)...)
+				return nil
+			})
+
+		log := openLog()
+		newSaver(log).Close(context.Background())
+		Expect(log.Close()).Should(Succeed())
+		Expect(saved).Should(Equal(urls(newLinks(3, 6))))
+	})
+})
@@ -1,87 +1,346 @@
 package saver
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/ozonva/ova-link-api/internal/flusher"
 	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
+	"github.com/ozonva/ova-link-api/internal/wal"
+	"github.com/rs/zerolog"
 )
 
+var (
+	ErrBufferFull = errors.New("saver buffer is full")
+	ErrClosed     = errors.New("saver is closed")
+)
+
+// OverflowPolicy decides what Save does when the queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the worker takes links out of the queue or ctx is done.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest queued links to make room.
+	OverflowDropOldest
+	// OverflowReject fails with ErrBufferFull.
+	OverflowReject
+)
+
+var overflowLabels = map[OverflowPolicy]string{
+	OverflowDropOldest: "drop_oldest",
+	OverflowReject:     "reject",
+}
+
 type Saver interface {
-	Save(entity link.Link)
-	Close()
+	Save(ctx context.Context, entity link.Link) error
+	// SaveBatch queues entities in order and returns how many of them were queued before an error.
+	SaveBatch(ctx context.Context, entities []link.Link) (int, error)
+	// Close flushes the queued links until ctx is done and returns how many were left unflushed.
+	// Calls after the first one return ErrClosed.
+	Close(ctx context.Context) (int, error)
 }
 
 type saveWorker struct {
-	save  chan link.Link
-	close chan bool
+	full  chan struct{}
+	close chan context.Context
+	done  chan int
 }
+
 type timeoutSaver struct {
-	entities []link.Link
-	flusher  flusher.Flusher
-	capacity uint
-	ticker   *time.Ticker
-	worker   saveWorker
-}
-
-func NewTimeOutSaver(capacity uint, flusher flusher.Flusher, savePeriodInSeconds uint) Saver {
-	ts := &timeoutSaver{
-		entities: make([]link.Link, 0, capacity),
-		flusher:  flusher,
-		capacity: capacity,
-		ticker:   time.NewTicker(time.Second * time.Duration(savePeriodInSeconds)),
-		worker: saveWorker{
-			save:  make(chan link.Link),
-			close: make(chan bool),
-		},
+	mu        sync.Mutex
+	entities  []link.Link
+	inflight  int
+	failed    bool
+	space     chan struct{}
+	closed    bool
+	closing   chan struct{}
+	closer    sync.Once
+	stop      context.CancelFunc
+	flusher   flusher.Flusher
+	capacity  uint
+	queueSize uint
+	overflow  OverflowPolicy
+	ticker    *time.Ticker
+	worker    saveWorker
+	metrics   *metrics.Metrics
+	log       *wal.Log
+	logger    zerolog.Logger
+}
+
+// NewTimeOutSaver flushes capacity links at once, or whatever is queued every savePeriodInSeconds.
+// After a flush that leaves links unprocessed only the ticker flushes, until a flush succeeds.
+// Up to queueSize links wait for the worker, then overflow applies. queueSize is at least capacity.
+func NewTimeOutSaver(
+	capacity uint,
+	queueSize uint,
+	overflow OverflowPolicy,
+	flusher flusher.Flusher,
+	savePeriodInSeconds uint,
+	saverMetrics *metrics.Metrics,
+) Saver {
+	ts := newTimeOutSaver(capacity, queueSize, overflow, flusher, savePeriodInSeconds, saverMetrics)
+	ts.startWorker()
+	return ts
+}
+
+// NewDurableSaver writes links into log before queueing them, Save returns once they are written.
+// Links left in log by a crashed run are flushed first. The log is reset to the links still
+// queued after every flush that persists links, so it only keeps links the flusher has not persisted yet.
+// Links dropped by OverflowDropOldest stay in the log until the next such flush.
+func NewDurableSaver(
+	capacity uint,
+	queueSize uint,
+	overflow OverflowPolicy,
+	flusher flusher.Flusher,
+	savePeriodInSeconds uint,
+	saverMetrics *metrics.Metrics,
+	log *wal.Log,
+	logger zerolog.Logger,
+) Saver {
+	ts := newTimeOutSaver(capacity, queueSize, overflow, flusher, savePeriodInSeconds, saverMetrics)
+	ts.log = log
+	ts.logger = logger
+
+	ts.entities = append(ts.entities, log.Replay()...)
+	ts.metrics.SetSaverBufferLength(len(ts.entities))
+	if len(ts.entities) > 0 {
+		ts.logger.Info().Int("links", len(ts.entities)).Msg("replaying links from the log")
+		ts.flush(context.Background(), metrics.FlushByReplay, len(ts.entities))
+	} else {
+		ts.mu.Lock()
+		ts.checkpoint()
+		ts.mu.Unlock()
 	}
 
 	ts.startWorker()
 	return ts
 }
 
-func (ts *timeoutSaver) Save(entity link.Link) {
-	ts.worker.save <- entity
+func newTimeOutSaver(
+	capacity uint,
+	queueSize uint,
+	overflow OverflowPolicy,
+	flusher flusher.Flusher,
+	savePeriodInSeconds uint,
+	saverMetrics *metrics.Metrics,
+) *timeoutSaver {
+	if queueSize < capacity {
+		queueSize = capacity
+	}
+	return &timeoutSaver{
+		entities:  make([]link.Link, 0, queueSize),
+		space:     make(chan struct{}),
+		closing:   make(chan struct{}),
+		flusher:   flusher,
+		capacity:  capacity,
+		queueSize: queueSize,
+		overflow:  overflow,
+		ticker:    time.NewTicker(time.Second * time.Duration(savePeriodInSeconds)),
+		worker: saveWorker{
+			full:  make(chan struct{}, 1),
+			close: make(chan context.Context, 1),
+			done:  make(chan int, 1),
+		},
+		metrics: saverMetrics,
+		logger:  zerolog.Nop(),
+	}
 }
 
-func (ts *timeoutSaver) Close() {
-	ts.worker.close <- true
-	close(ts.worker.save)
-	close(ts.worker.close)
+func (ts *timeoutSaver) Save(ctx context.Context, entity link.Link) error {
+	_, err := ts.SaveBatch(ctx, []link.Link{entity})
+	return err
 }
 
-func (ts *timeoutSaver) addToFlush(entity link.Link) {
-	if len(ts.entities) == int(ts.capacity) {
-		ts.flush()
+func (ts *timeoutSaver) SaveBatch(ctx context.Context, entities []link.Link) (int, error) {
+	saved := 0
+	for saved < len(entities) {
+		if err := ctx.Err(); err != nil {
+			return saved, err
+		}
+
+		ts.mu.Lock()
+		if ts.closed {
+			ts.mu.Unlock()
+			return saved, ErrClosed
+		}
+
+		free := int(ts.queueSize) - len(ts.entities)
+		if free <= 0 {
+			switch ts.overflow {
+			case OverflowDropOldest:
+				free = ts.dropOldest(len(entities) - saved)
+			case OverflowReject:
+				ts.mu.Unlock()
+				ts.metrics.AddSaverOverflow(overflowLabels[OverflowReject], len(entities)-saved)
+				return saved, ErrBufferFull
+			default:
+				space := ts.space
+				ts.mu.Unlock()
+				select {
+				case <-space:
+					continue
+				case <-ctx.Done():
+					return saved, ctx.Err()
+				case <-ts.closing:
+					return saved, ErrClosed
+				}
+			}
+		}
+
+		n := len(entities) - saved
+		if n > free {
+			n = free
+		}
+		ts.add(entities[saved : saved+n])
+		ts.mu.Unlock()
+		saved += n
 	}
-	ts.entities = append(ts.entities, entity)
+	return saved, nil
 }
 
-func (ts *timeoutSaver) flush() {
-	unprocessed := ts.flusher.Flush(ts.entities)
-	if len(unprocessed) > 0 {
-		for _, entity := range unprocessed {
-			ts.addToFlush(entity)
+func (ts *timeoutSaver) Close(ctx context.Context) (int, error) {
+	err := ErrClosed
+	unflushed := 0
+	ts.closer.Do(func() {
+		// Wake up the blocked Save calls before waiting for them to release the lock.
+		close(ts.closing)
+		ts.mu.Lock()
+		ts.closed = true
+		ts.mu.Unlock()
+
+		ts.worker.close <- ctx
+		select {
+		case unflushed = <-ts.worker.done:
+		case <-ctx.Done():
+			// Abort the flush the worker may be stuck in.
+			ts.stop()
+			ts.mu.Lock()
+			unflushed = len(ts.entities) + ts.inflight
+			ts.mu.Unlock()
+		}
+		err = nil
+		if unflushed > 0 {
+			err = ctx.Err()
+		}
+	})
+	return unflushed, err
+}
+
+// add expects ts.mu to be held.
+func (ts *timeoutSaver) add(entities []link.Link) {
+	if ts.log != nil {
+		if err := ts.log.Append(entities...); err != nil {
+			ts.logger.Error().Err(err).Int("links", len(entities)).Msg("failed to write links to the log, they are kept only in memory")
+		}
+	}
+	ts.entities = append(ts.entities, entities...)
+	ts.metrics.SetSaverBufferLength(len(ts.entities) + ts.inflight)
+	if !ts.failed && len(ts.entities) >= int(ts.capacity) {
+		select {
+		case ts.worker.full <- struct{}{}:
+		default:
 		}
+	}
+}
+
+// dropOldest expects ts.mu to be held.
+func (ts *timeoutSaver) dropOldest(count int) int {
+	if count > len(ts.entities) {
+		count = len(ts.entities)
+	}
+	ts.entities = append(ts.entities[:0], ts.entities[count:]...)
+	ts.metrics.AddSaverOverflow(overflowLabels[OverflowDropOldest], count)
+	return count
+}
+
+// flushFull flushes the links queued at the moment in whole multiples of capacity with a single
+// flush, so a large SaveBatch reaches the flusher as large chunks.
+// Nothing is flushed while the last flush failed, a signal sent before it is stale.
+func (ts *timeoutSaver) flushFull(ctx context.Context) {
+	ts.mu.Lock()
+	count := len(ts.entities) / int(ts.capacity) * int(ts.capacity)
+	if ts.failed {
+		count = 0
+	}
+	ts.mu.Unlock()
+
+	ts.flush(ctx, metrics.FlushByCapacity, count)
+}
+
+// flush writes the count oldest queued links and reports whether all of them were persisted.
+// The unprocessed ones go back to the head of the queue.
+func (ts *timeoutSaver) flush(ctx context.Context, trigger metrics.FlushTrigger, count int) bool {
+	ts.mu.Lock()
+	if count > len(ts.entities) {
+		count = len(ts.entities)
+	}
+	if count == 0 {
+		ts.mu.Unlock()
+		return true
+	}
+	batch := make([]link.Link, count)
+	copy(batch, ts.entities)
+	ts.entities = append(ts.entities[:0], ts.entities[count:]...)
+	ts.inflight = count
+	close(ts.space)
+	ts.space = make(chan struct{})
+	ts.mu.Unlock()
+
+	ts.metrics.IncSaverFlushes(trigger)
+	unprocessed := ts.flusher.Flush(ctx, batch)
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.entities = append(unprocessed, ts.entities...)
+	ts.inflight = 0
+	ts.failed = len(unprocessed) > 0
+	ts.metrics.SetSaverBufferLength(len(ts.entities))
+	// The log already holds every unprocessed link, rewriting it is only worth it when some were persisted.
+	if len(unprocessed) < count {
+		ts.checkpoint()
+	}
+	return !ts.failed
+}
+
+// checkpoint expects ts.mu to be held.
+func (ts *timeoutSaver) checkpoint() {
+	if ts.log == nil {
 		return
 	}
-	ts.entities = ts.entities[:0]
+	if err := ts.log.Reset(ts.entities); err != nil {
+		ts.logger.Error().Err(err).Msg("failed to truncate the log, persisted links may be saved again")
+	}
 }
 
 func (ts *timeoutSaver) startWorker() {
+	// Links are flushed in the background, long after the requests that saved them have finished.
+	ctx, stop := context.WithCancel(context.Background())
+	ts.stop = stop
 	go func(ts *timeoutSaver) {
-	exit:
+		defer stop()
 		for {
 			select {
 			case <-ts.ticker.C:
-				ts.flush()
-			case <-ts.worker.close:
+				ts.flush(ctx, metrics.FlushByTicker, ts.queued())
+			case <-ts.worker.full:
+				ts.flushFull(ctx)
+			case closeCtx := <-ts.worker.close:
 				ts.ticker.Stop()
-				ts.flush()
-				break exit
-			case entity := <-ts.worker.save:
-				ts.addToFlush(entity)
+				ts.flushFull(closeCtx)
+				ts.flush(closeCtx, metrics.FlushByClose, ts.queued())
+				ts.worker.done <- ts.queued()
+				return
 			}
 		}
 	}(ts)
 }
+
+func (ts *timeoutSaver) queued() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return len(ts.entities)
+}
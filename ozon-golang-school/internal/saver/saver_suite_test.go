@@ -1,6 +1,7 @@
 package saver_test
 
 import (
+	"os"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -8,6 +9,12 @@ import (
 )
 
 func TestSaver(t *testing.T) {
+	// The crash tests run the test binary again as the process to kill.
+	if dir := os.Getenv(crashDirEnv); dir != "" {
+		runCrashingSaver(dir)
+		return
+	}
+
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Saver Suite")
 }
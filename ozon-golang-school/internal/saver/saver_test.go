@@ -1,22 +1,85 @@
 package saver_test
 
 import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 	"github.com/ozonva/ova-link-api/internal/flusher"
 	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/metrics"
 	"github.com/ozonva/ova-link-api/internal/mocks"
 	"github.com/ozonva/ova-link-api/internal/saver"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
+// gateFlusher holds every flush until release is closed or ctx is done.
+type gateFlusher struct {
+	started chan []link.Link
+	release chan bool
+	mu      sync.Mutex
+	flushed []string
+}
+
+func newGateFlusher() *gateFlusher {
+	return &gateFlusher{
+		started: make(chan []link.Link, 10),
+		release: make(chan bool),
+	}
+}
+
+func (f *gateFlusher) Flush(ctx context.Context, entities []link.Link) []link.Link {
+	f.started <- entities
+	select {
+	case <-f.release:
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, entity := range entities {
+			f.flushed = append(f.flushed, entity.Url)
+		}
+		return nil
+	case <-ctx.Done():
+		return entities
+	}
+}
+
+func (f *gateFlusher) Flushed() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.flushed
+}
+
+// failingFlusher leaves every link unprocessed and counts the flushes.
+type failingFlusher struct {
+	flushes int32
+}
+
+func (f *failingFlusher) Flush(ctx context.Context, entities []link.Link) []link.Link {
+	atomic.AddInt32(&f.flushes, 1)
+	return entities
+}
+
+func (f *failingFlusher) Flushes() int32 {
+	return atomic.LoadInt32(&f.flushes)
+}
+
 var _ = Describe("Saver", func() {
 	var repo *mocks.MockRepo
 	var ctrl *gomock.Controller
+	var registry *prometheus.Registry
+	var saverMetrics *metrics.Metrics
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		repo = mocks.NewMockRepo(ctrl)
+		registry = prometheus.NewRegistry()
+		saverMetrics = metrics.New(registry)
 	})
 
 	AfterEach(func() {
@@ -25,31 +88,267 @@ var _ = Describe("Saver", func() {
 
 	It("Saving by timeout. Should be call when timeout is expired.", func() {
 		defer GinkgoRecover()
-		flusherImpl := flusher.NewFlusher(3, repo)
-		timeoutSaver := saver.NewTimeOutSaver(5, flusherImpl, 1)
+		flusherImpl := flusher.NewFlusher(3, repo, saverMetrics)
+		timeoutSaver := saver.NewTimeOutSaver(5, 10, saver.OverflowBlock, flusherImpl, 1, saverMetrics)
 
-		repo.EXPECT().AddEntities(gomock.Any()).Times(2).Return(nil)
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(2).Return(nil)
 
-		timeoutSaver.Save(*link.New(1, "1"))
-		timeoutSaver.Save(*link.New(1, "2"))
-		timeoutSaver.Save(*link.New(2, "3"))
-		timeoutSaver.Save(*link.New(2, "4"))
+		timeoutSaver.Save(context.Background(), *link.New(1, "1"))
+		timeoutSaver.Save(context.Background(), *link.New(1, "2"))
+		timeoutSaver.Save(context.Background(), *link.New(2, "3"))
+		timeoutSaver.Save(context.Background(), *link.New(2, "4"))
 
 		time.Sleep(2 * time.Second)
 	})
 
 	It("Saving by close. Should be call immediately. Timeout should be stopped", func() {
-		flusherImpl := flusher.NewFlusher(3, repo)
-		timeoutSaver := saver.NewTimeOutSaver(5, flusherImpl, 2)
+		flusherImpl := flusher.NewFlusher(3, repo, saverMetrics)
+		timeoutSaver := saver.NewTimeOutSaver(5, 10, saver.OverflowBlock, flusherImpl, 2, saverMetrics)
 
-		repo.EXPECT().AddEntities(gomock.Any()).Times(2).Return(nil)
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(2).Return(nil)
 
-		timeoutSaver.Save(*link.New(1, "1"))
-		timeoutSaver.Save(*link.New(1, "2"))
-		timeoutSaver.Save(*link.New(2, "3"))
-		timeoutSaver.Save(*link.New(2, "4"))
+		timeoutSaver.Save(context.Background(), *link.New(1, "1"))
+		timeoutSaver.Save(context.Background(), *link.New(1, "2"))
+		timeoutSaver.Save(context.Background(), *link.New(2, "3"))
+		timeoutSaver.Save(context.Background(), *link.New(2, "4"))
 
-		timeoutSaver.Close()
+		timeoutSaver.Close(context.Background())
 		time.Sleep(1 * time.Second)
 	})
+
+	It("Saving batch. Should be split by flusher chunk size.", func() {
+		flusherImpl := flusher.NewFlusher(3, repo, saverMetrics)
+		timeoutSaver := saver.NewTimeOutSaver(10, 10, saver.OverflowBlock, flusherImpl, 5, saverMetrics)
+
+		gomock.InOrder(
+			repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(3)).Times(2).Return(nil),
+			repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(1)).Times(1).Return(nil),
+		)
+
+		timeoutSaver.SaveBatch(context.Background(), []link.Link{
+			*link.New(1, "1"),
+			*link.New(1, "2"),
+			*link.New(2, "3"),
+			*link.New(2, "4"),
+			*link.New(3, "5"),
+			*link.New(3, "6"),
+			*link.New(3, "7"),
+		})
+
+		timeoutSaver.Close(context.Background())
+	})
+
+	It("Closing twice. Should flush once.", func() {
+		flusherImpl := flusher.NewFlusher(3, repo, saverMetrics)
+		timeoutSaver := saver.NewTimeOutSaver(5, 10, saver.OverflowBlock, flusherImpl, 5, saverMetrics)
+
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+		timeoutSaver.Save(context.Background(), *link.New(1, "1"))
+
+		timeoutSaver.Close(context.Background())
+		timeoutSaver.Close(context.Background())
+	})
+
+	It("Saving over capacity. Should report flush triggers and buffer length.", func() {
+		flusherImpl := flusher.NewFlusher(3, repo, saverMetrics)
+		timeoutSaver := saver.NewTimeOutSaver(2, 10, saver.OverflowBlock, flusherImpl, 5, saverMetrics)
+
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(2)).Times(1).Return(nil)
+		repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(1)).Times(1).Return(nil)
+
+		timeoutSaver.SaveBatch(context.Background(), []link.Link{
+			*link.New(1, "1"),
+			*link.New(1, "2"),
+			*link.New(1, "3"),
+		})
+		timeoutSaver.Close(context.Background())
+
+		expected := `
+# HELP ova_link_api_saver_buffer_length Links waiting in the saver buffer.
+# TYPE ova_link_api_saver_buffer_length gauge
+ova_link_api_saver_buffer_length 0
+# HELP ova_link_api_saver_flushes_total Saver flushes by trigger: capacity, ticker, close or replay.
+# TYPE ova_link_api_saver_flushes_total counter
+ova_link_api_saver_flushes_total{trigger="capacity"} 1
+ova_link_api_saver_flushes_total{trigger="close"} 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"ova_link_api_saver_buffer_length",
+			"ova_link_api_saver_flushes_total",
+		)).Should(Succeed())
+	})
+
+	It("Saving batch of several capacities. Should flush them in one chunk.", func() {
+		flusherImpl := flusher.NewFlusher(100, repo, saverMetrics)
+		timeoutSaver := saver.NewTimeOutSaver(2, 10, saver.OverflowBlock, flusherImpl, 5, saverMetrics)
+
+		gomock.InOrder(
+			repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(6)).Times(1).Return(nil),
+			repo.EXPECT().AddEntities(gomock.Any(), gomock.Len(1)).Times(1).Return(nil),
+		)
+
+		entities := make([]link.Link, 0, 7)
+		for i := 1; i <= 7; i++ {
+			entities = append(entities, *link.New(1, strconv.Itoa(i)))
+		}
+		timeoutSaver.SaveBatch(context.Background(), entities)
+		timeoutSaver.Close(context.Background())
+	})
+
+	It("Flush failed with the queue over capacity. Should wait for the ticker instead of flushing on every save.", func() {
+		failing := &failingFlusher{}
+		timeoutSaver := saver.NewTimeOutSaver(2, 100, saver.OverflowBlock, failing, 3600, saverMetrics)
+
+		for i := 0; i < 2; i++ {
+			Expect(timeoutSaver.Save(context.Background(), *link.New(1, strconv.Itoa(i)))).Should(Succeed())
+		}
+		Eventually(failing.Flushes).Should(Equal(int32(1)))
+
+		for i := 2; i < 10; i++ {
+			Expect(timeoutSaver.Save(context.Background(), *link.New(1, strconv.Itoa(i)))).Should(Succeed())
+		}
+		Consistently(failing.Flushes, 200*time.Millisecond).Should(Equal(int32(1)))
+		timeoutSaver.Close(context.Background())
+	})
+
+	Describe("Backpressure.", func() {
+		var gate *gateFlusher
+		var links []link.Link
+
+		BeforeEach(func() {
+			gate = newGateFlusher()
+			links = make([]link.Link, 0, 7)
+			for i := 0; i < 7; i++ {
+				links = append(links, *link.New(1, "https://test.com/"+strconv.Itoa(i)))
+			}
+		})
+
+		// startStuckFlush saves two links and waits until the worker is stuck flushing them.
+		startStuckFlush := func(s saver.Saver) {
+			Expect(s.SaveBatch(context.Background(), links[0:2])).Should(Equal(2))
+			Eventually(gate.started).Should(Receive(Equal(links[0:2])))
+		}
+
+		It("Worker is stuck in a flush. Save should queue links until the queue is full.", func() {
+			s := saver.NewTimeOutSaver(2, 4, saver.OverflowBlock, gate, 3600, saverMetrics)
+			startStuckFlush(s)
+
+			Expect(s.SaveBatch(context.Background(), links[2:6])).Should(Equal(4))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			Expect(s.Save(ctx, links[6])).Should(MatchError(context.DeadlineExceeded))
+
+			close(gate.release)
+			Expect(s.Close(context.Background())).Should(Equal(0))
+			Expect(gate.Flushed()).Should(ConsistOf(
+				"https://test.com/0", "https://test.com/1", "https://test.com/2",
+				"https://test.com/3", "https://test.com/4", "https://test.com/5",
+			))
+		})
+
+		It("Queue is full with the block policy. Save should continue once the worker takes links.", func() {
+			s := saver.NewTimeOutSaver(2, 2, saver.OverflowBlock, gate, 3600, saverMetrics)
+			startStuckFlush(s)
+			Expect(s.SaveBatch(context.Background(), links[2:4])).Should(Equal(2))
+
+			saved := make(chan error)
+			go func() {
+				saved <- s.Save(context.Background(), links[4])
+			}()
+			Consistently(saved).ShouldNot(Receive())
+
+			close(gate.release)
+			Eventually(saved).Should(Receive(BeNil()))
+			Expect(s.Close(context.Background())).Should(Equal(0))
+		})
+
+		It("Queue is full with the reject policy. Save should fail with ErrBufferFull.", func() {
+			s := saver.NewTimeOutSaver(2, 2, saver.OverflowReject, gate, 3600, saverMetrics)
+			startStuckFlush(s)
+
+			saved, err := s.SaveBatch(context.Background(), links[2:5])
+			Expect(err).Should(MatchError(saver.ErrBufferFull))
+			Expect(saved).Should(Equal(2))
+
+			close(gate.release)
+			Expect(s.Close(context.Background())).Should(Equal(0))
+			Expect(gate.Flushed()).Should(HaveLen(4))
+
+			expected := `
+# HELP ova_link_api_saver_overflow_links_total Links dropped or rejected by the saver because its queue was full, by overflow policy.
+# TYPE ova_link_api_saver_overflow_links_total counter
+ova_link_api_saver_overflow_links_total{policy="reject"} 1
+`
+			Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "ova_link_api_saver_overflow_links_total")).Should(Succeed())
+		})
+
+		It("Queue is full with the drop oldest policy. Save should drop the oldest queued links.", func() {
+			s := saver.NewTimeOutSaver(2, 3, saver.OverflowDropOldest, gate, 3600, saverMetrics)
+			startStuckFlush(s)
+
+			Expect(s.SaveBatch(context.Background(), links[2:7])).Should(Equal(5))
+
+			close(gate.release)
+			Expect(s.Close(context.Background())).Should(Equal(0))
+			Expect(gate.Flushed()).Should(ConsistOf(
+				"https://test.com/0", "https://test.com/1",
+				"https://test.com/4", "https://test.com/5", "https://test.com/6",
+			))
+
+			expected := `
+# HELP ova_link_api_saver_overflow_links_total Links dropped or rejected by the saver because its queue was full, by overflow policy.
+# TYPE ova_link_api_saver_overflow_links_total counter
+ova_link_api_saver_overflow_links_total{policy="drop_oldest"} 2
+`
+			Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "ova_link_api_saver_overflow_links_total")).Should(Succeed())
+		})
+
+		It("Save is blocked by a full queue when the saver is closed. Should fail with ErrClosed.", func() {
+			s := saver.NewTimeOutSaver(2, 2, saver.OverflowBlock, gate, 3600, saverMetrics)
+			startStuckFlush(s)
+			Expect(s.SaveBatch(context.Background(), links[2:4])).Should(Equal(2))
+
+			saved := make(chan error)
+			go func() {
+				saved <- s.Save(context.Background(), links[4])
+			}()
+			Consistently(saved).ShouldNot(Receive())
+
+			closed := make(chan int)
+			go func() {
+				defer GinkgoRecover()
+				unflushed, err := s.Close(context.Background())
+				Expect(err).ShouldNot(HaveOccurred())
+				closed <- unflushed
+			}()
+			Eventually(saved).Should(Receive(MatchError(saver.ErrClosed)))
+
+			close(gate.release)
+			Eventually(closed).Should(Receive(Equal(0)))
+		})
+
+		It("Saver is closed. Save and Close should fail with ErrClosed.", func() {
+			s := saver.NewTimeOutSaver(2, 2, saver.OverflowBlock, gate, 3600, saverMetrics)
+			Expect(s.Close(context.Background())).Should(Equal(0))
+
+			Expect(s.Save(context.Background(), links[0])).Should(MatchError(saver.ErrClosed))
+			_, err := s.Close(context.Background())
+			Expect(err).Should(MatchError(saver.ErrClosed))
+		})
+
+		It("Close deadline passes while the worker is stuck. Should report unflushed links.", func() {
+			s := saver.NewTimeOutSaver(2, 4, saver.OverflowBlock, gate, 3600, saverMetrics)
+			startStuckFlush(s)
+			Expect(s.SaveBatch(context.Background(), links[2:5])).Should(Equal(3))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			unflushed, err := s.Close(ctx)
+			Expect(err).Should(MatchError(context.DeadlineExceeded))
+			Expect(unflushed).Should(Equal(5))
+			Expect(gate.Flushed()).Should(BeEmpty())
+		})
+	})
 })
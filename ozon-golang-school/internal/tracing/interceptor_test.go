@@ -0,0 +1,108 @@
+package tracing_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/tracing"
+	"github.com/ozonva/ova-link-api/internal/tracing/tracingtest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	result := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, kv := range span.Attributes {
+		result[kv.Key] = kv.Value
+	}
+	return result
+}
+
+var _ = Describe("Interceptor", func() {
+	var exporter *tracetest.InMemoryExporter
+
+	BeforeEach(func() {
+		var provider trace.TracerProvider
+		provider, exporter = tracingtest.NewProvider()
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+
+	It("Unary call. Should create a server span around the handler.", func() {
+		info := &grpc.UnaryServerInfo{FullMethod: "/ova.link.api.LinkAPI/ListLink"}
+		var handlerSpan trace.SpanContext
+		_, err := tracing.UnaryServerInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return nil, nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		spans := exporter.GetSpans()
+		Expect(spans).Should(HaveLen(1))
+		Expect(spans[0].Name).Should(Equal("/ova.link.api.LinkAPI/ListLink"))
+		Expect(spans[0].SpanKind).Should(Equal(trace.SpanKindServer))
+		Expect(spans[0].SpanContext.SpanID()).Should(Equal(handlerSpan.SpanID()))
+		Expect(attributes(spans[0])).Should(And(
+			HaveKeyWithValue(attribute.Key("rpc.service"), attribute.StringValue("ova.link.api.LinkAPI")),
+			HaveKeyWithValue(attribute.Key("rpc.method"), attribute.StringValue("ListLink")),
+			HaveKeyWithValue(attribute.Key("rpc.grpc.status_code"), attribute.IntValue(0)),
+		))
+	})
+
+	It("Unary call failed. Should record the error and its code.", func() {
+		info := &grpc.UnaryServerInfo{FullMethod: "/ova.link.api.LinkAPI/DescribeLink"}
+		_, err := tracing.UnaryServerInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "link not found")
+		})
+		Expect(status.Code(err)).Should(Equal(codes.NotFound))
+
+		spans := exporter.GetSpans()
+		Expect(spans).Should(HaveLen(1))
+		Expect(spans[0].Status.Code).Should(Equal(otelcodes.Error))
+		Expect(spans[0].Events).Should(HaveLen(1))
+		Expect(attributes(spans[0])).Should(HaveKeyWithValue(attribute.Key("rpc.grpc.status_code"), attribute.IntValue(int(codes.NotFound))))
+	})
+
+	It("Stream call with a parent from metadata. Should continue the client trace.", func() {
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		parentID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		md := metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		stream := &serverStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+
+		info := &grpc.StreamServerInfo{FullMethod: "/ova.link.api.LinkAPI/ExportLinks", IsServerStream: true}
+		var handlerSpan trace.SpanContext
+		err := tracing.StreamServerInterceptor()(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			handlerSpan = trace.SpanContextFromContext(stream.Context())
+			return nil
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		spans := exporter.GetSpans()
+		Expect(spans).Should(HaveLen(1))
+		Expect(spans[0].SpanContext.TraceID()).Should(Equal(traceID))
+		Expect(spans[0].Parent.SpanID()).Should(Equal(parentID))
+		Expect(handlerSpan.SpanID()).Should(Equal(spans[0].SpanContext.SpanID()))
+	})
+})
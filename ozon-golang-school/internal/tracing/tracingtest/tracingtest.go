@@ -0,0 +1,13 @@
+// Package tracingtest provides tracing helpers for tests, it is kept apart so the service does not link the test SDK.
+package tracingtest
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// NewProvider keeps every span in memory as soon as it ends, so tests can inspect them right after a call.
+func NewProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
@@ -0,0 +1,358 @@
+package wal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+)
+
+type SyncPolicy int
+
+const (
+	// SyncAlways fsyncs every append before it returns.
+	SyncAlways SyncPolicy = iota
+	// SyncInterval fsyncs in the background, a crash loses at most one interval of links.
+	SyncInterval
+	// SyncNever leaves writing back to the OS, links survive a killed process but not a power loss.
+	SyncNever
+)
+
+type Options struct {
+	// SegmentSize is the size after which appends go to a new segment file.
+	SegmentSize  int64
+	Sync         SyncPolicy
+	SyncInterval time.Duration
+}
+
+const (
+	segmentExt    = ".wal"
+	headerSize    = 8
+	maxRecordSize = 1 << 20
+)
+
+var ErrClosed = errors.New("log is closed")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// record keeps the fields of a link the saver writes to the repo.
+type record struct {
+	UserID       uint64    `json:"user_id"`
+	Url          string    `json:"url"`
+	CanonicalUrl string    `json:"canonical_url"`
+	Description  string    `json:"description"`
+	Tags         []string  `json:"tags,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Log is an append-only log of links split into segment files. Every record is framed by its
+// length and CRC-32C, so a record torn by a crash is detected and dropped on the next Open.
+type Log struct {
+	mu       sync.Mutex
+	dir      string
+	options  Options
+	segments []uint64
+	active   *os.File
+	size     int64
+	dirty    bool
+	syncErr  error
+	closed   bool
+	replayed []link.Link
+	stop     chan bool
+	done     chan bool
+}
+
+// Open reads links left in dir by a previous run and starts a new segment for appends.
+func Open(dir string, options Options) (*Log, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Log{
+		dir:      dir,
+		options:  options,
+		segments: segments,
+		stop:     make(chan bool),
+		done:     make(chan bool),
+	}
+	for _, id := range segments {
+		entities, err := readSegment(l.path(id))
+		if err != nil {
+			return nil, err
+		}
+		l.replayed = append(l.replayed, entities...)
+	}
+
+	// A torn tail of the last segment is never appended to.
+	if err := l.roll(); err != nil {
+		return nil, err
+	}
+
+	if options.Sync == SyncInterval {
+		go l.syncPeriodically()
+	} else {
+		close(l.done)
+	}
+	return l, nil
+}
+
+// Replay returns links read by Open, they are still in the log until the next Reset.
+func (l *Log) Replay() []link.Link {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.replayed
+}
+
+func (l *Log) Append(entities ...link.Link) error {
+	data, err := encode(entities)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return ErrClosed
+	}
+	if l.syncErr != nil {
+		return l.syncErr
+	}
+
+	if l.size > 0 && l.options.SegmentSize > 0 && l.size+int64(len(data)) > l.options.SegmentSize {
+		if err := l.roll(); err != nil {
+			return err
+		}
+	}
+	return l.write(data, l.options.Sync == SyncAlways)
+}
+
+// Reset replaces the whole log with entities, it is called once the rest has been persisted.
+// The new segment is synced before old ones are removed, a crash in between replays both.
+func (l *Log) Reset(entities []link.Link) error {
+	data, err := encode(entities)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return ErrClosed
+	}
+
+	old := len(l.segments)
+	if err := l.roll(); err != nil {
+		return err
+	}
+	if err := l.write(data, true); err != nil {
+		return err
+	}
+
+	for _, id := range l.segments[:old] {
+		if err := os.Remove(l.path(id)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	l.segments = append([]uint64(nil), l.segments[old:]...)
+	l.replayed = nil
+	return syncDir(l.dir)
+}
+
+func (l *Log) Close() error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	l.mu.Unlock()
+
+	if l.options.Sync == SyncInterval {
+		close(l.stop)
+	}
+	<-l.done
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.active.Sync(); err != nil {
+		_ = l.active.Close()
+		return err
+	}
+	return l.active.Close()
+}
+
+func (l *Log) write(data []byte, sync bool) error {
+	n, err := l.active.Write(data)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if !sync {
+		l.dirty = true
+		return nil
+	}
+	l.dirty = false
+	return l.active.Sync()
+}
+
+// roll syncs and closes the active segment and creates the next one.
+func (l *Log) roll() error {
+	if l.active != nil {
+		if err := l.active.Sync(); err != nil {
+			return err
+		}
+		if err := l.active.Close(); err != nil {
+			return err
+		}
+		l.active = nil
+	}
+
+	var id uint64 = 1
+	if len(l.segments) > 0 {
+		id = l.segments[len(l.segments)-1] + 1
+	}
+	file, err := os.OpenFile(l.path(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	l.active = file
+	l.size = 0
+	l.dirty = false
+	l.segments = append(l.segments, id)
+	return syncDir(l.dir)
+}
+
+func (l *Log) syncPeriodically() {
+	defer close(l.done)
+	ticker := time.NewTicker(l.options.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.mu.Lock()
+			if l.dirty && l.syncErr == nil {
+				l.syncErr = l.active.Sync()
+				l.dirty = false
+			}
+			l.mu.Unlock()
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+func (l *Log) path(id uint64) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%020d%s", id, segmentExt))
+}
+
+func listSegments(dir string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]uint64, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, id)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	return segments, nil
+}
+
+// readSegment reads records up to the first one that is incomplete or corrupted.
+func readSegment(path string) ([]link.Link, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []link.Link
+	for len(data) >= headerSize {
+		size := binary.BigEndian.Uint32(data[0:4])
+		sum := binary.BigEndian.Uint32(data[4:8])
+		if size > maxRecordSize || int(size) > len(data)-headerSize {
+			break
+		}
+		payload := data[headerSize : headerSize+size]
+		if crc32.Checksum(payload, crcTable) != sum {
+			break
+		}
+
+		var r record
+		if err := json.Unmarshal(payload, &r); err != nil {
+			break
+		}
+		result = append(result, link.Link{
+			UserID:       r.UserID,
+			Url:          r.Url,
+			CanonicalUrl: r.CanonicalUrl,
+			Description:  r.Description,
+			Tags:         r.Tags,
+			CreatedAt:    r.CreatedAt,
+		})
+		data = data[headerSize+size:]
+	}
+	return result, nil
+}
+
+func encode(entities []link.Link) ([]byte, error) {
+	var buf bytes.Buffer
+	header := make([]byte, headerSize)
+	for _, entity := range entities {
+		payload, err := json.Marshal(record{
+			UserID:       entity.UserID,
+			Url:          entity.Url,
+			CanonicalUrl: entity.CanonicalUrl,
+			Description:  entity.Description,
+			Tags:         entity.Tags,
+			CreatedAt:    entity.CreatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(payload) > maxRecordSize {
+			return nil, fmt.Errorf("link %q is too large for the log", entity.Url)
+		}
+
+		binary.BigEndian.PutUint32(header[0:4], uint32(len(payload)))
+		binary.BigEndian.PutUint32(header[4:8], crc32.Checksum(payload, crcTable))
+		buf.Write(header)
+		buf.Write(payload)
+	}
+	return buf.Bytes(), nil
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return file.Sync()
+}
@@ -0,0 +1,157 @@
+package wal_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/ozonva/ova-link-api/internal/wal"
+)
+
+func newLinks(from int, to int) []link.Link {
+	result := make([]link.Link, 0, to-from)
+	for i := from; i < to; i++ {
+		entity := link.New(uint64(i), "https://test.com/"+strconv.Itoa(i))
+		entity.Description = "description " + strconv.Itoa(i)
+		entity.Tags = []string{"tag" + strconv.Itoa(i)}
+		entity.CreatedAt = time.Date(2021, 10, i, 0, 0, 0, 0, time.UTC)
+		entity.UpdatedAt = time.Time{}
+		result = append(result, *entity)
+	}
+	return result
+}
+
+func segments(dir string) []string {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.wal"))
+	Expect(err).ShouldNot(HaveOccurred())
+	return paths
+}
+
+var _ = Describe("Wal", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "wal")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).Should(Succeed())
+	})
+
+	reopen := func(options wal.Options) *wal.Log {
+		log, err := wal.Open(dir, options)
+		Expect(err).ShouldNot(HaveOccurred())
+		return log
+	}
+
+	It("Append and reopen. Should replay links in order.", func() {
+		log := reopen(wal.Options{})
+		Expect(log.Replay()).Should(BeEmpty())
+		Expect(log.Append(newLinks(1, 3)...)).Should(Succeed())
+		Expect(log.Append(newLinks(3, 4)...)).Should(Succeed())
+		Expect(log.Close()).Should(Succeed())
+
+		log = reopen(wal.Options{})
+		defer log.Close()
+		Expect(log.Replay()).Should(Equal(newLinks(1, 4)))
+	})
+
+	It("Torn last record. Should replay the records before it.", func() {
+		log := reopen(wal.Options{})
+		Expect(log.Append(newLinks(1, 4)...)).Should(Succeed())
+		Expect(log.Close()).Should(Succeed())
+
+		path := segments(dir)[0]
+		info, err := os.Stat(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(os.Truncate(path, info.Size()-5)).Should(Succeed())
+
+		log = reopen(wal.Options{})
+		Expect(log.Replay()).Should(Equal(newLinks(1, 3)))
+		Expect(log.Append(newLinks(4, 5)...)).Should(Succeed())
+		Expect(log.Close()).Should(Succeed())
+
+		log = reopen(wal.Options{})
+		defer log.Close()
+		Expect(log.Replay()).Should(Equal(append(newLinks(1, 3), newLinks(4, 5)...)))
+	})
+
+	It("Corrupted record. Should stop replaying the segment at it.", func() {
+		log := reopen(wal.Options{})
+		Expect(log.Append(newLinks(1, 4)...)).Should(Succeed())
+		Expect(log.Close()).Should(Succeed())
+
+		path := segments(dir)[0]
+		data, err := ioutil.ReadFile(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		data[len(data)/2] ^= 0xff
+		Expect(ioutil.WriteFile(path, data, 0o644)).Should(Succeed())
+
+		log = reopen(wal.Options{})
+		defer log.Close()
+		Expect(len(log.Replay())).Should(BeNumerically("<", 3))
+		Expect(newLinks(1, 4)).Should(ContainElements(log.Replay()))
+	})
+
+	It("Segment is full. Should continue in a new one.", func() {
+		log := reopen(wal.Options{SegmentSize: 300})
+		for _, entity := range newLinks(1, 6) {
+			Expect(log.Append(entity)).Should(Succeed())
+		}
+		Expect(log.Close()).Should(Succeed())
+		Expect(len(segments(dir))).Should(BeNumerically(">", 2))
+
+		log = reopen(wal.Options{SegmentSize: 300})
+		defer log.Close()
+		Expect(log.Replay()).Should(Equal(newLinks(1, 6)))
+	})
+
+	It("Reset. Should keep only the given links.", func() {
+		log := reopen(wal.Options{SegmentSize: 300})
+		for _, entity := range newLinks(1, 6) {
+			Expect(log.Append(entity)).Should(Succeed())
+		}
+		Expect(len(segments(dir))).Should(BeNumerically(">", 1))
+		Expect(log.Reset(newLinks(4, 5))).Should(Succeed())
+		Expect(segments(dir)).Should(HaveLen(1))
+		Expect(log.Append(newLinks(6, 7)...)).Should(Succeed())
+		Expect(log.Close()).Should(Succeed())
+
+		log = reopen(wal.Options{})
+		Expect(log.Replay()).Should(Equal(append(newLinks(4, 5), newLinks(6, 7)...)))
+		Expect(log.Reset(nil)).Should(Succeed())
+		Expect(log.Replay()).Should(BeEmpty())
+		Expect(log.Close()).Should(Succeed())
+
+		log = reopen(wal.Options{})
+		defer log.Close()
+		Expect(log.Replay()).Should(BeEmpty())
+	})
+
+	It("Sync by interval. Should keep appended links.", func() {
+		log := reopen(wal.Options{Sync: wal.SyncInterval, SyncInterval: 10 * time.Millisecond})
+		Expect(log.Append(newLinks(1, 3)...)).Should(Succeed())
+		time.Sleep(30 * time.Millisecond)
+		Expect(log.Close()).Should(Succeed())
+
+		log = reopen(wal.Options{})
+		defer log.Close()
+		Expect(log.Replay()).Should(Equal(newLinks(1, 3)))
+	})
+
+	It("Closed log. Should refuse to append.", func() {
+		log := reopen(wal.Options{Sync: wal.SyncNever})
+		Expect(log.Close()).Should(Succeed())
+		Expect(log.Close()).Should(Succeed())
+
+		Expect(log.Append(newLinks(1, 2)...)).Should(MatchError(wal.ErrClosed))
+		Expect(log.Reset(nil)).Should(MatchError(wal.ErrClosed))
+	})
+})
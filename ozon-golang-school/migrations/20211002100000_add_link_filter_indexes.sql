@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_user_created ON links (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_created ON links (created_at);
+CREATE INDEX IF NOT EXISTS idx_url_trgm ON links USING gin (url gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_description_trgm ON links USING gin (description gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_tags_trgm ON links USING gin (('#' || tags || '#') gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tags_trgm;
+DROP INDEX IF EXISTS idx_description_trgm;
+DROP INDEX IF EXISTS idx_url_trgm;
+DROP INDEX IF EXISTS idx_created;
+DROP INDEX IF EXISTS idx_user_created;
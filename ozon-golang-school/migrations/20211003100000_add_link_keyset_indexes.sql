@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_created_id ON links (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_user_created_id ON links (user_id, created_at, id);
+DROP INDEX IF EXISTS idx_created;
+DROP INDEX IF EXISTS idx_user_created;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_user_created ON links (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_created ON links (created_at);
+DROP INDEX IF EXISTS idx_user_created_id;
+DROP INDEX IF EXISTS idx_created_id;
@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE links ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_deleted ON links (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_deleted;
+ALTER TABLE links DROP COLUMN IF EXISTS deleted_at;
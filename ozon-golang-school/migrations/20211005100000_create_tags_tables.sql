@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tags
+(
+    id   bigserial primary key,
+    name text not null unique
+);
+
+CREATE TABLE IF NOT EXISTS link_tags
+(
+    link_id int8 not null references links (id) on delete cascade,
+    tag_id  int8 not null references tags (id) on delete cascade,
+    primary key (link_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_tags_tag ON link_tags (tag_id);
+
+INSERT INTO tags (name)
+SELECT DISTINCT tag
+FROM links, unnest(string_to_array(links.tags, '#')) AS tag
+WHERE tag <> ''
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO link_tags (link_id, tag_id)
+SELECT links.id, tags.id
+FROM links, unnest(string_to_array(links.tags, '#')) AS tag
+JOIN tags ON tags.name = tag
+ON CONFLICT DO NOTHING;
+
+DROP INDEX IF EXISTS idx_tags_trgm;
+ALTER TABLE links DROP COLUMN IF EXISTS tags;
+
+-- +goose Down
+ALTER TABLE links ADD COLUMN IF NOT EXISTS tags text not null default '';
+
+UPDATE links
+SET tags = link_tag_names.tags
+FROM (
+    SELECT link_tags.link_id, string_agg(tags.name, '#' ORDER BY tags.name) AS tags
+    FROM link_tags
+    JOIN tags ON tags.id = link_tags.tag_id
+    GROUP BY link_tags.link_id
+) AS link_tag_names
+WHERE links.id = link_tag_names.link_id;
+
+CREATE INDEX IF NOT EXISTS idx_tags_trgm ON links USING gin (('#' || tags || '#') gin_trgm_ops);
+
+DROP INDEX IF EXISTS idx_link_tags_tag;
+DROP TABLE IF EXISTS link_tags;
+DROP TABLE IF EXISTS tags;
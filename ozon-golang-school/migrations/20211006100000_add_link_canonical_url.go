@@ -0,0 +1,111 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/ozonva/ova-link-api/internal/link"
+	"github.com/pressly/goose/v3"
+)
+
+// canonicalBatchSize is the number of existing links canonicalized per query.
+const canonicalBatchSize = 1000
+
+// duplicatesQuery pairs every link not in the trash with the first saved link of its user with the same canonical_url.
+const duplicatesQuery = `
+	SELECT id, first_value(id) OVER (PARTITION BY user_id, canonical_url ORDER BY id) AS kept_id
+	FROM links
+	WHERE deleted_at IS NULL`
+
+func init() {
+	goose.AddMigration(upAddLinkCanonicalUrl, downAddLinkCanonicalUrl)
+}
+
+// upAddLinkCanonicalUrl fills canonical_url of existing links with link.Canonicalize, the same
+// function new links go through, so old and new saves of a page meet in idx_user_canonical_url.
+func upAddLinkCanonicalUrl(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE links ADD COLUMN IF NOT EXISTS canonical_url text`); err != nil {
+		return err
+	}
+
+	var lastID uint64
+	for {
+		urls, err := selectUrls(tx, lastID)
+		if err != nil {
+			return err
+		}
+		if len(urls) == 0 {
+			break
+		}
+
+		for _, row := range urls {
+			var entity link.Link
+			entity.SetUrl(row.url)
+			if _, err := tx.Exec(`UPDATE links SET canonical_url = $1 WHERE id = $2`, entity.CanonicalUrl, row.id); err != nil {
+				return err
+			}
+			lastID = row.id
+		}
+	}
+
+	// Newer duplicates are moved to the trash so that they can be reviewed and purged, the link
+	// saved first keeps its ID and gets their tags, so none of them is lost with the purge.
+	_, err := tx.Exec(`
+		INSERT INTO link_tags (link_id, tag_id)
+		SELECT duplicates.kept_id, link_tags.tag_id
+		FROM (` + duplicatesQuery + `) duplicates
+		JOIN link_tags ON link_tags.link_id = duplicates.id
+		WHERE duplicates.id <> duplicates.kept_id
+		ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE links
+		SET deleted_at = now()
+		WHERE id IN (
+			SELECT id FROM (` + duplicatesQuery + `) duplicates
+			WHERE id <> kept_id
+		)`)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`ALTER TABLE links ALTER COLUMN canonical_url SET NOT NULL`); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_user_canonical_url ON links (user_id, canonical_url) WHERE deleted_at IS NULL`)
+	return err
+}
+
+func downAddLinkCanonicalUrl(tx *sql.Tx) error {
+	if _, err := tx.Exec(`DROP INDEX IF EXISTS idx_user_canonical_url`); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`ALTER TABLE links DROP COLUMN IF EXISTS canonical_url`)
+	return err
+}
+
+type linkUrl struct {
+	id  uint64
+	url string
+}
+
+// selectUrls reads the next batch of links after lastID, the rows are closed before they are updated.
+func selectUrls(tx *sql.Tx, lastID uint64) ([]linkUrl, error) {
+	rows, err := tx.Query(`SELECT id, url FROM links WHERE id > $1 ORDER BY id LIMIT $2`, lastID, canonicalBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]linkUrl, 0, canonicalBatchSize)
+	for rows.Next() {
+		var row linkUrl
+		if err := rows.Scan(&row.id, &row.url); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+)
+
+var _ = Describe("Add link canonical url.", func() {
+	It("Existing links. Should canonicalize them like new ones and merge the tags of duplicates before the unique index is built.", func() {
+		db, mock, err := sqlxmock.Newx()
+		Expect(err).ShouldNot(HaveOccurred())
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("ALTER TABLE links ADD COLUMN IF NOT EXISTS canonical_url text").
+			WillReturnResult(sqlxmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT id, url FROM links WHERE id > \\$1 ORDER BY id LIMIT \\$2").
+			WithArgs(0, canonicalBatchSize).
+			WillReturnRows(sqlxmock.NewRows([]string{"id", "url"}).
+				AddRow(1, "http://X.com:80/a/?utm_source=x&page=2").
+				AddRow(2, "not a url"))
+		mock.ExpectExec("UPDATE links SET canonical_url = \\$1 WHERE id = \\$2").
+			WithArgs("https://x.com/a?page=2", 1).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE links SET canonical_url = \\$1 WHERE id = \\$2").
+			WithArgs("not a url", 2).
+			WillReturnResult(sqlxmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT id, url FROM links WHERE id > \\$1 ORDER BY id LIMIT \\$2").
+			WithArgs(2, canonicalBatchSize).
+			WillReturnRows(sqlxmock.NewRows([]string{"id", "url"}))
+		mock.ExpectExec("INSERT INTO link_tags \\(link_id, tag_id\\) SELECT duplicates.kept_id, link_tags.tag_id .* " +
+			"JOIN link_tags ON link_tags.link_id = duplicates.id WHERE duplicates.id <> duplicates.kept_id ON CONFLICT DO NOTHING").
+			WillReturnResult(sqlxmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE links SET deleted_at = now\\(\\) .* ORDER BY id\\) AS kept_id .* WHERE id <> kept_id").
+			WillReturnResult(sqlxmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE links ALTER COLUMN canonical_url SET NOT NULL").
+			WillReturnResult(sqlxmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE UNIQUE INDEX IF NOT EXISTS idx_user_canonical_url").
+			WillReturnResult(sqlxmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		tx, err := db.Begin()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(upAddLinkCanonicalUrl(tx)).Should(Succeed())
+		Expect(tx.Commit()).Should(Succeed())
+		Expect(mock.ExpectationsWereMet()).Should(Succeed())
+	})
+})
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS title text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS page_description text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS image_url text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS favicon_url text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS page_canonical_url text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS enriched_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_not_enriched ON links (id) WHERE enriched_at IS NULL AND deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_not_enriched;
+ALTER TABLE links
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS page_description,
+    DROP COLUMN IF EXISTS image_url,
+    DROP COLUMN IF EXISTS favicon_url,
+    DROP COLUMN IF EXISTS page_canonical_url,
+    DROP COLUMN IF EXISTS enriched_at;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS link_checks
+(
+    id          bigserial primary key,
+    link_id     int8        not null references links (id) on delete cascade,
+    status_code int4        not null default 0,
+    final_url   text        not null default '',
+    error       text        not null default '',
+    checked_at  timestamptz not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_checks_link_checked ON link_checks (link_id, checked_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS link_checks;
@@ -0,0 +1,61 @@
+-- +goose Up
+-- Generated columns can not read other tables, so tag names are copied into links by a trigger.
+ALTER TABLE links ADD COLUMN IF NOT EXISTS tag_names text NOT NULL DEFAULT '';
+
+UPDATE links
+SET tag_names = coalesce((
+    SELECT string_agg(t.name, ' ' ORDER BY t.name)
+    FROM link_tags lt
+             JOIN tags t ON t.id = lt.tag_id
+    WHERE lt.link_id = links.id
+), '');
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION links_sync_tag_names() RETURNS trigger AS
+$$
+DECLARE
+    changed_link_id int8;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_link_id := OLD.link_id;
+    ELSE
+        changed_link_id := NEW.link_id;
+    END IF;
+
+    UPDATE links
+    SET tag_names = coalesce((
+        SELECT string_agg(t.name, ' ' ORDER BY t.name)
+        FROM link_tags lt
+                 JOIN tags t ON t.id = lt.tag_id
+        WHERE lt.link_id = changed_link_id
+    ), '')
+    WHERE id = changed_link_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER link_tags_sync_tag_names
+    AFTER INSERT OR DELETE
+    ON link_tags
+    FOR EACH ROW
+EXECUTE FUNCTION links_sync_tag_names();
+
+ALTER TABLE links
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+                setweight(to_tsvector('english', title), 'A') ||
+                setweight(to_tsvector('simple', tag_names), 'A') ||
+                setweight(to_tsvector('english', description), 'B') ||
+                setweight(to_tsvector('english', page_description), 'C') ||
+                setweight(to_tsvector('simple', url), 'D')
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_search_vector ON links USING gin (search_vector);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_search_vector;
+ALTER TABLE links DROP COLUMN IF EXISTS search_vector;
+DROP TRIGGER IF EXISTS link_tags_sync_tag_names ON link_tags;
+DROP FUNCTION IF EXISTS links_sync_tag_names();
+ALTER TABLE links DROP COLUMN IF EXISTS tag_names;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- link_id has no foreign key: events of purged links must still be delivered.
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id         bigserial primary key,
+    link_id    int8        not null,
+    event_type text        not null,
+    payload    jsonb       not null,
+    created_at timestamptz not null default now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox;
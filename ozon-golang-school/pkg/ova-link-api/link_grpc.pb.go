@@ -19,10 +19,22 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LinkAPIClient interface {
-	CreateLink(ctx context.Context, in *CreateLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateLink(ctx context.Context, in *CreateLinkRequest, opts ...grpc.CallOption) (*CreateLinkResponse, error)
+	MultiCreateLink(ctx context.Context, in *MultiCreateLinkRequest, opts ...grpc.CallOption) (*MultiCreateLinkResponse, error)
+	MultiCreateLinkStream(ctx context.Context, opts ...grpc.CallOption) (LinkAPI_MultiCreateLinkStreamClient, error)
 	DescribeLink(ctx context.Context, in *DescribeLinkRequest, opts ...grpc.CallOption) (*DescribeLinkResponse, error)
 	ListLink(ctx context.Context, in *ListLinkRequest, opts ...grpc.CallOption) (*ListLinkResponse, error)
 	DeleteLink(ctx context.Context, in *DeleteLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreLink(ctx context.Context, in *RestoreLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PurgeDeletedLinks(ctx context.Context, in *PurgeDeletedLinksRequest, opts ...grpc.CallOption) (*PurgeDeletedLinksResponse, error)
+	UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*DescribeLinkResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error)
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error)
+	ListBrokenLinks(ctx context.Context, in *ListBrokenLinksRequest, opts ...grpc.CallOption) (*ListBrokenLinksResponse, error)
+	SearchLinks(ctx context.Context, in *SearchLinksRequest, opts ...grpc.CallOption) (*SearchLinksResponse, error)
+	ImportLinks(ctx context.Context, opts ...grpc.CallOption) (LinkAPI_ImportLinksClient, error)
+	ExportLinks(ctx context.Context, in *ExportLinksRequest, opts ...grpc.CallOption) (LinkAPI_ExportLinksClient, error)
 }
 
 type linkAPIClient struct {
@@ -33,8 +45,8 @@ func NewLinkAPIClient(cc grpc.ClientConnInterface) LinkAPIClient {
 	return &linkAPIClient{cc}
 }
 
-func (c *linkAPIClient) CreateLink(ctx context.Context, in *CreateLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *linkAPIClient) CreateLink(ctx context.Context, in *CreateLinkRequest, opts ...grpc.CallOption) (*CreateLinkResponse, error) {
+	out := new(CreateLinkResponse)
 	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/CreateLink", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -42,6 +54,49 @@ func (c *linkAPIClient) CreateLink(ctx context.Context, in *CreateLinkRequest, o
 	return out, nil
 }
 
+func (c *linkAPIClient) MultiCreateLink(ctx context.Context, in *MultiCreateLinkRequest, opts ...grpc.CallOption) (*MultiCreateLinkResponse, error) {
+	out := new(MultiCreateLinkResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/MultiCreateLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) MultiCreateLinkStream(ctx context.Context, opts ...grpc.CallOption) (LinkAPI_MultiCreateLinkStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LinkAPI_ServiceDesc.Streams[0], "/ova.link.api.LinkAPI/MultiCreateLinkStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linkAPIMultiCreateLinkStreamClient{stream}
+	return x, nil
+}
+
+type LinkAPI_MultiCreateLinkStreamClient interface {
+	Send(*CreateLinkRequest) error
+	CloseAndRecv() (*MultiCreateLinkResponse, error)
+	grpc.ClientStream
+}
+
+type linkAPIMultiCreateLinkStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *linkAPIMultiCreateLinkStreamClient) Send(m *CreateLinkRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *linkAPIMultiCreateLinkStreamClient) CloseAndRecv() (*MultiCreateLinkResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(MultiCreateLinkResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *linkAPIClient) DescribeLink(ctx context.Context, in *DescribeLinkRequest, opts ...grpc.CallOption) (*DescribeLinkResponse, error) {
 	out := new(DescribeLinkResponse)
 	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/DescribeLink", in, out, opts...)
@@ -69,14 +124,164 @@ func (c *linkAPIClient) DeleteLink(ctx context.Context, in *DeleteLinkRequest, o
 	return out, nil
 }
 
+func (c *linkAPIClient) RestoreLink(ctx context.Context, in *RestoreLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/RestoreLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) PurgeDeletedLinks(ctx context.Context, in *PurgeDeletedLinksRequest, opts ...grpc.CallOption) (*PurgeDeletedLinksResponse, error) {
+	out := new(PurgeDeletedLinksResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/PurgeDeletedLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*DescribeLinkResponse, error) {
+	out := new(DescribeLinkResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/UpdateLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/ListTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*RenameTagResponse, error) {
+	out := new(RenameTagResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/RenameTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*MergeTagsResponse, error) {
+	out := new(MergeTagsResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/MergeTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) ListBrokenLinks(ctx context.Context, in *ListBrokenLinksRequest, opts ...grpc.CallOption) (*ListBrokenLinksResponse, error) {
+	out := new(ListBrokenLinksResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/ListBrokenLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) SearchLinks(ctx context.Context, in *SearchLinksRequest, opts ...grpc.CallOption) (*SearchLinksResponse, error) {
+	out := new(SearchLinksResponse)
+	err := c.cc.Invoke(ctx, "/ova.link.api.LinkAPI/SearchLinks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linkAPIClient) ImportLinks(ctx context.Context, opts ...grpc.CallOption) (LinkAPI_ImportLinksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LinkAPI_ServiceDesc.Streams[1], "/ova.link.api.LinkAPI/ImportLinks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linkAPIImportLinksClient{stream}
+	return x, nil
+}
+
+type LinkAPI_ImportLinksClient interface {
+	Send(*ImportLinksRequest) error
+	CloseAndRecv() (*ImportLinksResponse, error)
+	grpc.ClientStream
+}
+
+type linkAPIImportLinksClient struct {
+	grpc.ClientStream
+}
+
+func (x *linkAPIImportLinksClient) Send(m *ImportLinksRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *linkAPIImportLinksClient) CloseAndRecv() (*ImportLinksResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportLinksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *linkAPIClient) ExportLinks(ctx context.Context, in *ExportLinksRequest, opts ...grpc.CallOption) (LinkAPI_ExportLinksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LinkAPI_ServiceDesc.Streams[2], "/ova.link.api.LinkAPI/ExportLinks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &linkAPIExportLinksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LinkAPI_ExportLinksClient interface {
+	Recv() (*ExportLinksResponse, error)
+	grpc.ClientStream
+}
+
+type linkAPIExportLinksClient struct {
+	grpc.ClientStream
+}
+
+func (x *linkAPIExportLinksClient) Recv() (*ExportLinksResponse, error) {
+	m := new(ExportLinksResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LinkAPIServer is the server API for LinkAPI service.
 // All implementations must embed UnimplementedLinkAPIServer
 // for forward compatibility
 type LinkAPIServer interface {
-	CreateLink(context.Context, *CreateLinkRequest) (*emptypb.Empty, error)
+	CreateLink(context.Context, *CreateLinkRequest) (*CreateLinkResponse, error)
+	MultiCreateLink(context.Context, *MultiCreateLinkRequest) (*MultiCreateLinkResponse, error)
+	MultiCreateLinkStream(LinkAPI_MultiCreateLinkStreamServer) error
 	DescribeLink(context.Context, *DescribeLinkRequest) (*DescribeLinkResponse, error)
 	ListLink(context.Context, *ListLinkRequest) (*ListLinkResponse, error)
 	DeleteLink(context.Context, *DeleteLinkRequest) (*emptypb.Empty, error)
+	RestoreLink(context.Context, *RestoreLinkRequest) (*emptypb.Empty, error)
+	PurgeDeletedLinks(context.Context, *PurgeDeletedLinksRequest) (*PurgeDeletedLinksResponse, error)
+	UpdateLink(context.Context, *UpdateLinkRequest) (*DescribeLinkResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error)
+	MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error)
+	ListBrokenLinks(context.Context, *ListBrokenLinksRequest) (*ListBrokenLinksResponse, error)
+	SearchLinks(context.Context, *SearchLinksRequest) (*SearchLinksResponse, error)
+	ImportLinks(LinkAPI_ImportLinksServer) error
+	ExportLinks(*ExportLinksRequest, LinkAPI_ExportLinksServer) error
 	mustEmbedUnimplementedLinkAPIServer()
 }
 
@@ -84,9 +289,15 @@ type LinkAPIServer interface {
 type UnimplementedLinkAPIServer struct {
 }
 
-func (UnimplementedLinkAPIServer) CreateLink(context.Context, *CreateLinkRequest) (*emptypb.Empty, error) {
+func (UnimplementedLinkAPIServer) CreateLink(context.Context, *CreateLinkRequest) (*CreateLinkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateLink not implemented")
 }
+func (UnimplementedLinkAPIServer) MultiCreateLink(context.Context, *MultiCreateLinkRequest) (*MultiCreateLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MultiCreateLink not implemented")
+}
+func (UnimplementedLinkAPIServer) MultiCreateLinkStream(LinkAPI_MultiCreateLinkStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method MultiCreateLinkStream not implemented")
+}
 func (UnimplementedLinkAPIServer) DescribeLink(context.Context, *DescribeLinkRequest) (*DescribeLinkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DescribeLink not implemented")
 }
@@ -96,6 +307,36 @@ func (UnimplementedLinkAPIServer) ListLink(context.Context, *ListLinkRequest) (*
 func (UnimplementedLinkAPIServer) DeleteLink(context.Context, *DeleteLinkRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteLink not implemented")
 }
+func (UnimplementedLinkAPIServer) RestoreLink(context.Context, *RestoreLinkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreLink not implemented")
+}
+func (UnimplementedLinkAPIServer) PurgeDeletedLinks(context.Context, *PurgeDeletedLinksRequest) (*PurgeDeletedLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedLinks not implemented")
+}
+func (UnimplementedLinkAPIServer) UpdateLink(context.Context, *UpdateLinkRequest) (*DescribeLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLink not implemented")
+}
+func (UnimplementedLinkAPIServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedLinkAPIServer) RenameTag(context.Context, *RenameTagRequest) (*RenameTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedLinkAPIServer) MergeTags(context.Context, *MergeTagsRequest) (*MergeTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedLinkAPIServer) ListBrokenLinks(context.Context, *ListBrokenLinksRequest) (*ListBrokenLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBrokenLinks not implemented")
+}
+func (UnimplementedLinkAPIServer) SearchLinks(context.Context, *SearchLinksRequest) (*SearchLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchLinks not implemented")
+}
+func (UnimplementedLinkAPIServer) ImportLinks(LinkAPI_ImportLinksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportLinks not implemented")
+}
+func (UnimplementedLinkAPIServer) ExportLinks(*ExportLinksRequest, LinkAPI_ExportLinksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportLinks not implemented")
+}
 func (UnimplementedLinkAPIServer) mustEmbedUnimplementedLinkAPIServer() {}
 
 // UnsafeLinkAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -127,6 +368,50 @@ func _LinkAPI_CreateLink_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LinkAPI_MultiCreateLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiCreateLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).MultiCreateLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/MultiCreateLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).MultiCreateLink(ctx, req.(*MultiCreateLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_MultiCreateLinkStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LinkAPIServer).MultiCreateLinkStream(&linkAPIMultiCreateLinkStreamServer{stream})
+}
+
+type LinkAPI_MultiCreateLinkStreamServer interface {
+	SendAndClose(*MultiCreateLinkResponse) error
+	Recv() (*CreateLinkRequest, error)
+	grpc.ServerStream
+}
+
+type linkAPIMultiCreateLinkStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *linkAPIMultiCreateLinkStreamServer) SendAndClose(m *MultiCreateLinkResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *linkAPIMultiCreateLinkStreamServer) Recv() (*CreateLinkRequest, error) {
+	m := new(CreateLinkRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _LinkAPI_DescribeLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DescribeLinkRequest)
 	if err := dec(in); err != nil {
@@ -181,6 +466,197 @@ func _LinkAPI_DeleteLink_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LinkAPI_RestoreLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).RestoreLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/RestoreLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).RestoreLink(ctx, req.(*RestoreLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_PurgeDeletedLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).PurgeDeletedLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/PurgeDeletedLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).PurgeDeletedLinks(ctx, req.(*PurgeDeletedLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_UpdateLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).UpdateLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/UpdateLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).UpdateLink(ctx, req.(*UpdateLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/ListTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/RenameTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/MergeTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_ListBrokenLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBrokenLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).ListBrokenLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/ListBrokenLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).ListBrokenLinks(ctx, req.(*ListBrokenLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_SearchLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinkAPIServer).SearchLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ova.link.api.LinkAPI/SearchLinks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinkAPIServer).SearchLinks(ctx, req.(*SearchLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinkAPI_ImportLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LinkAPIServer).ImportLinks(&linkAPIImportLinksServer{stream})
+}
+
+type LinkAPI_ImportLinksServer interface {
+	SendAndClose(*ImportLinksResponse) error
+	Recv() (*ImportLinksRequest, error)
+	grpc.ServerStream
+}
+
+type linkAPIImportLinksServer struct {
+	grpc.ServerStream
+}
+
+func (x *linkAPIImportLinksServer) SendAndClose(m *ImportLinksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *linkAPIImportLinksServer) Recv() (*ImportLinksRequest, error) {
+	m := new(ImportLinksRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _LinkAPI_ExportLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportLinksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinkAPIServer).ExportLinks(m, &linkAPIExportLinksServer{stream})
+}
+
+type LinkAPI_ExportLinksServer interface {
+	Send(*ExportLinksResponse) error
+	grpc.ServerStream
+}
+
+type linkAPIExportLinksServer struct {
+	grpc.ServerStream
+}
+
+func (x *linkAPIExportLinksServer) Send(m *ExportLinksResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // LinkAPI_ServiceDesc is the grpc.ServiceDesc for LinkAPI service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -192,6 +668,10 @@ var LinkAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateLink",
 			Handler:    _LinkAPI_CreateLink_Handler,
 		},
+		{
+			MethodName: "MultiCreateLink",
+			Handler:    _LinkAPI_MultiCreateLink_Handler,
+		},
 		{
 			MethodName: "DescribeLink",
 			Handler:    _LinkAPI_DescribeLink_Handler,
@@ -204,7 +684,55 @@ var LinkAPI_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteLink",
 			Handler:    _LinkAPI_DeleteLink_Handler,
 		},
+		{
+			MethodName: "RestoreLink",
+			Handler:    _LinkAPI_RestoreLink_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedLinks",
+			Handler:    _LinkAPI_PurgeDeletedLinks_Handler,
+		},
+		{
+			MethodName: "UpdateLink",
+			Handler:    _LinkAPI_UpdateLink_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _LinkAPI_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _LinkAPI_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _LinkAPI_MergeTags_Handler,
+		},
+		{
+			MethodName: "ListBrokenLinks",
+			Handler:    _LinkAPI_ListBrokenLinks_Handler,
+		},
+		{
+			MethodName: "SearchLinks",
+			Handler:    _LinkAPI_SearchLinks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MultiCreateLinkStream",
+			Handler:       _LinkAPI_MultiCreateLinkStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportLinks",
+			Handler:       _LinkAPI_ImportLinks_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportLinks",
+			Handler:       _LinkAPI_ExportLinks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "link.proto",
 }